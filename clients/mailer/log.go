@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"context"
+	"user-service/common/logger"
+	"user-service/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer writes mail to the log instead of sending it. Bodies carry live
+// reset and verification links, so they are only logged in development
+// environments; elsewhere just the recipient and subject are.
+type LogMailer struct{}
+
+func NewLogMailer() IMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message Message) error {
+	entry := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"mail_to":      message.To,
+		"mail_subject": message.Subject,
+	})
+	if config.Config.IsDevelopment() {
+		entry = entry.WithField("mail_body", message.Body)
+	}
+	entry.Info("mail logged instead of sent")
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"user-service/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type IMailer interface {
+	Send(context.Context, Message) error
+}
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+// NewMailer returns the implementation selected by mail.driver. Without a
+// known driver, development environments fall back to the log mailer so local
+// runs never send real email; elsewhere sending fails until one is configured.
+func NewMailer() IMailer {
+	switch config.Config.Mail.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(config.Config.Mail)
+	case DriverLog:
+		return NewLogMailer()
+	}
+
+	if config.Config.IsDevelopment() {
+		return NewLogMailer()
+	}
+
+	return &unconfiguredMailer{driver: config.Config.Mail.Driver}
+}
+
+type unconfiguredMailer struct {
+	driver string
+}
+
+func (m *unconfiguredMailer) Send(context.Context, Message) error {
+	return fmt.Errorf("mail driver %q is not supported, set mail.driver to smtp or log", m.driver)
+}
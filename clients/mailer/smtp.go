@@ -0,0 +1,109 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+	"user-service/config"
+)
+
+// defaultTimeout bounds a delivery whose context has no deadline of its own.
+const defaultTimeout = 30 * time.Second
+
+type SMTPMailer struct {
+	config config.Mail
+}
+
+func NewSMTPMailer(config config.Mail) IMailer {
+	return &SMTPMailer{config: config}
+}
+
+// Send delivers message over one connection whose deadline comes from ctx, so
+// an unresponsive server cannot hold the caller longer than it allows.
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	address := net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// The same steps as smtp.SendMail, which cannot take a deadline.
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: m.config.Host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.config.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(m.config.From)
+	if err != nil {
+		return err
+	}
+
+	err = client.Rcpt(message.To)
+	if err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write([]byte(m.body(message)))
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (m *SMTPMailer) body(message Message) string {
+	headers := []string{
+		fmt.Sprintf("From: %s", m.config.From),
+		fmt.Sprintf("To: %s", message.To),
+		fmt.Sprintf("Subject: %s", message.Subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+
+	return strings.Join(headers, "\r\n") + "\r\n\r\n" + message.Body
+}
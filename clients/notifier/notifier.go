@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"user-service/clients/mailer"
+	"user-service/domain/models"
+)
+
+// INotifier delivers account related messages to a user. Implementations
+// decide the channel; the service only hands over what has to be said.
+type INotifier interface {
+	SendPasswordReset(ctx context.Context, user *models.User, link string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, user *models.User, link string, expiresAt time.Time) error
+}
+
+type MailNotifier struct {
+	mailer mailer.IMailer
+}
+
+func NewMailNotifier(mailer mailer.IMailer) INotifier {
+	return &MailNotifier{mailer: mailer}
+}
+
+func (n *MailNotifier) SendPasswordReset(ctx context.Context, user *models.User, link string, expiresAt time.Time) error {
+	return n.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to set a new password. It expires at %s.\n\n%s\n\nIf you did not ask for this, you can ignore this email.",
+			user.Name, expiresAt.Format(time.RFC1123), link,
+		),
+	})
+}
+
+func (n *MailNotifier) SendEmailVerification(ctx context.Context, user *models.User, link string, expiresAt time.Time) error {
+	return n.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below. It expires at %s.\n\n%s",
+			user.Name, expiresAt.Format(time.RFC1123), link,
+		),
+	})
+}
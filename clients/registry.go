@@ -0,0 +1,25 @@
+package clients
+
+import (
+	"user-service/clients/mailer"
+	"user-service/clients/notifier"
+)
+
+type Registry struct{}
+
+type IClientRegistry interface {
+	GetMailer() mailer.IMailer
+	GetNotifier() notifier.INotifier
+}
+
+func NewClientRegistry() IClientRegistry {
+	return &Registry{}
+}
+
+func (r *Registry) GetMailer() mailer.IMailer {
+	return mailer.NewMailer()
+}
+
+func (r *Registry) GetNotifier() notifier.INotifier {
+	return notifier.NewMailNotifier(r.GetMailer())
+}
@@ -1,101 +0,0 @@
-package cmd
-
-import (
-	"fmt"
-	"net/http"
-	"time"
-	"user-service/common/response"
-	"user-service/config"
-	"user-service/constants"
-	"user-service/controllers"
-	"user-service/database/seeders"
-	"user-service/domain/models"
-	"user-service/middlewares"
-	"user-service/repositories"
-	"user-service/routes"
-	"user-service/services"
-
-	"github.com/didip/tollbooth"
-	"github.com/didip/tollbooth/limiter"
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
-	"github.com/spf13/cobra"
-)
-
-var command = &cobra.Command{
-	Use:   "serve",
-	Short: "Start the server",
-	Run: func(cmd *cobra.Command, args []string) {
-		_ = godotenv.Load()
-		config.Init()
-		db, err := config.InitDatabase()
-		if err != nil {
-			panic(err)
-		}
-
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			panic(err)
-		}
-
-		time.Local = loc
-
-		err = db.AutoMigrate(
-			&models.Role{},
-			&models.User{},
-		)
-		if err != nil {
-			panic(err)
-		}
-
-		seeders.NewSeederRegistry(db).Run()
-
-		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository)
-		controllers := controllers.NewControllerRegistry(service)
-
-		router := gin.Default()
-		router.Use(middlewares.HandlePanic())
-		router.NoRoute(func(ctx *gin.Context) {
-			ctx.JSON(http.StatusNotFound, response.Response{
-				Status:  constants.Error,
-				Message: fmt.Sprintf("Path %s", http.StatusText(http.StatusNotFound)),
-			})
-		})
-		router.GET("/", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusNotFound, response.Response{
-				Status:  constants.Success,
-				Message: "wellcome to user service",
-			})
-		})
-		router.Use(func(ctx *gin.Context) {
-			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-			ctx.Writer.Header().Set("Access-Control-Allow-Method", "GET, POST, PUT, DELETE, OPTIONS")
-			ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at")
-			ctx.Next()
-		})
-
-		lmt := tollbooth.NewLimiter(
-			config.Config.RateLimiterMaxRequest,
-			&limiter.ExpirableOptions{
-				DefaultExpirationTTL: time.Duration(config.Config.RateLimiterTimeSecond) * time.Second,
-			},
-		)
-		router.Use(middlewares.RateLimiter(lmt))
-
-		group := router.Group("/api/v1")
-		route := routes.NewRouteRegistry(controllers, group)
-		route.Serve()
-
-		port := fmt.Sprintf(":%d", config.Config.Port)
-
-		router.Run(port)
-	},
-}
-
-func Run() {
-	err := command.Execute()
-	if err != nil {
-		panic(err)
-	}
-}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"user-service/database/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCommand = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+}
+
+var migrateUpCommand = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrator.Up(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+
+		return nil
+	},
+}
+
+var migrateDownCommand = &cobra.Command{
+	Use:   "down N",
+	Short: "Roll back the N most recent migrations",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("N must be a positive number, got %q", args[0])
+		}
+
+		migrator, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		reverted, err := migrator.Down(cmd.Context(), n)
+		if err != nil {
+			return err
+		}
+
+		if len(reverted) == 0 {
+			fmt.Println("no migration to roll back")
+		}
+
+		return nil
+	},
+}
+
+var migrateStatusCommand = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrator.Status(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%06d  %-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	migrateCommand.AddCommand(migrateUpCommand, migrateDownCommand, migrateStatusCommand)
+	rootCommand.AddCommand(migrateCommand)
+}
+
+func newMigrator() (migrations.IMigrator, error) {
+	db, err := bootstrap()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.NewMigrator(db), nil
+}
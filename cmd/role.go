@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var roleCommand = &cobra.Command{
+	Use:   "role",
+	Short: "Manage roles",
+}
+
+var roleListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List roles and their permissions",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := bootstrap()
+		if err != nil {
+			return err
+		}
+
+		_, service, err := newServices(db)
+		if err != nil {
+			return err
+		}
+
+		roles, err := service.GetRole().FindAll(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\tCODE\tNAME\t2FA\tPERMISSIONS")
+		for _, role := range roles {
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%t\t%s\n", role.ID, role.Code, role.Name, role.TwoFactorRequired, strings.Join(role.Permissions, ","))
+		}
+
+		return writer.Flush()
+	},
+}
+
+func init() {
+	roleCommand.AddCommand(roleListCommand)
+	rootCommand.AddCommand(roleCommand)
+}
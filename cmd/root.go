@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"time"
+	"user-service/clients"
+	"user-service/common/keyset"
+	"user-service/common/logger"
+	"user-service/config"
+	"user-service/repositories"
+	"user-service/services"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var rootCommand = &cobra.Command{
+	Use:          "user-service",
+	Short:        "User service API and operational commands",
+	SilenceUsage: true,
+}
+
+// bootstrap loads the configuration and connects to the database; every
+// subcommand starts with it.
+func bootstrap() (*gorm.DB, error) {
+	_ = godotenv.Load()
+	config.Init()
+	logger.Init()
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return nil, err
+	}
+
+	time.Local = loc
+
+	return config.InitDatabase()
+}
+
+// newServices builds the same registries the HTTP server uses, so commands go
+// through the service layer and its business rules.
+func newServices(db *gorm.DB) (repositories.IRepositoryRegistry, services.IServiceRegistry, error) {
+	err := keyset.Init()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repository := repositories.NewRepositoryRegistry(db)
+	client := clients.NewClientRegistry()
+	service := services.NewServiceRegistry(repository, client)
+
+	return repository, service, nil
+}
+
+func Run() {
+	err := rootCommand.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
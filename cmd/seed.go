@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"user-service/database/seeders"
+
+	"github.com/spf13/cobra"
+)
+
+var seedCommand = &cobra.Command{
+	Use:   "seed",
+	Short: "Seed the default roles, permissions and administrator",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := bootstrap()
+		if err != nil {
+			return err
+		}
+
+		seeders.NewSeederRegistry(db).Run()
+
+		return nil
+	},
+}
+
+func init() {
+	rootCommand.AddCommand(seedCommand)
+}
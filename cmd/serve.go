@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+	"user-service/common/health"
+	"user-service/common/keyset"
+	"user-service/common/locale"
+	"user-service/common/metrics"
+	"user-service/common/response"
+	"user-service/common/tracing"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/controllers"
+	"user-service/database/migrations"
+	"user-service/database/seeders"
+	"user-service/middlewares"
+	"user-service/routes"
+
+	"github.com/didip/tollbooth"
+	"github.com/didip/tollbooth/limiter"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+
+	errConstants "user-service/constants/error"
+)
+
+var (
+	autoMigrate bool
+	autoSeed    bool
+)
+
+var serveCommand = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the server",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		db, err := bootstrap()
+		if err != nil {
+			panic(err)
+		}
+
+		shutdownTracing, err := tracing.Init(cmd.Context())
+		if err != nil {
+			panic(err)
+		}
+
+		// The schema is owned by `migrate up` and the base data by `seed`;
+		// serving only does either when explicitly asked to, e.g. for local
+		// development.
+		if autoMigrate {
+			_, err = migrations.NewMigrator(db).Up(cmd.Context())
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		if autoSeed {
+			seeders.NewSeederRegistry(db).Run()
+		}
+
+		_, service, err := newServices(db)
+		if err != nil {
+			panic(err)
+		}
+
+		controllers := controllers.NewControllerRegistry(service)
+		middlewares.Init(service)
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			panic(err)
+		}
+		metrics.RegisterDB(sqlDB, config.Config.Database.Name)
+
+		router := gin.New()
+		router.Use(otelgin.Middleware(config.Config.AppName))
+		router.Use(middlewares.RequestID())
+		router.Use(middlewares.Locale())
+		router.Use(middlewares.Metrics())
+		router.Use(middlewares.HandlePanic())
+		router.NoRoute(func(ctx *gin.Context) {
+			response.HttpResponse(response.ParamHTTPResp{
+				Err: errConstants.ErrRouteNotFound,
+				Gin: ctx,
+			})
+		})
+		router.GET("/", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, response.Response{
+				Status:  constants.Success,
+				Message: locale.Text(locale.FromContext(ctx.Request.Context()), locale.TextWelcome),
+			})
+		})
+		router.GET("/.well-known/jwks.json", func(ctx *gin.Context) {
+			ctx.Header("Cache-Control", "public, max-age=300")
+			ctx.JSON(http.StatusOK, keyset.JWKS())
+		})
+		// The probes answer orchestrators rather than people, so their
+		// messages stay untranslated.
+		router.GET("/healthz", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, response.Response{
+				Status:  constants.Success,
+				Message: http.StatusText(http.StatusOK),
+				Data:    health.Report{Status: health.StatusUp, Checks: []health.Check{}},
+			})
+		})
+		checker := health.NewChecker(db)
+		router.GET("/readyz", func(ctx *gin.Context) {
+			report := checker.Readiness(ctx.Request.Context())
+			if report.Status != health.StatusUp {
+				ctx.JSON(http.StatusServiceUnavailable, response.Response{
+					Status:  constants.Error,
+					Message: http.StatusText(http.StatusServiceUnavailable),
+					Data:    report,
+				})
+				return
+			}
+
+			ctx.JSON(http.StatusOK, response.Response{
+				Status:  constants.Success,
+				Message: http.StatusText(http.StatusOK),
+				Data:    report,
+			})
+		})
+		router.Use(func(ctx *gin.Context) {
+			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+			ctx.Writer.Header().Set("Access-Control-Allow-Method", "GET, POST, PUT, DELETE, OPTIONS")
+			ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-signature, x-nonce, x-request-at, x-request-id")
+			ctx.Next()
+		})
+
+		lmt := tollbooth.NewLimiter(
+			config.Config.RateLimiterMaxRequest,
+			&limiter.ExpirableOptions{
+				DefaultExpirationTTL: time.Duration(config.Config.RateLimiterTimeSecond) * time.Second,
+			},
+		)
+		router.Use(middlewares.RateLimiter(lmt))
+
+		group := router.Group("/api/v1")
+		route := routes.NewRouteRegistry(controllers, group)
+		route.Serve()
+
+		serverConfig := config.Config.HttpServer
+		server := &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.Config.Port),
+			Handler:           router,
+			ReadTimeout:       seconds(serverConfig.ReadTimeoutSecond, 30),
+			ReadHeaderTimeout: seconds(serverConfig.ReadHeaderTimeoutSecond, 10),
+			WriteTimeout:      seconds(serverConfig.WriteTimeoutSecond, 30),
+			IdleTimeout:       seconds(serverConfig.IdleTimeoutSecond, 120),
+		}
+
+		listener, err := net.Listen("tcp", server.Addr)
+		if err != nil {
+			panic(err)
+		}
+
+		go func() {
+			err := server.Serve(listener)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logrus.Fatalf("server stopped: %v", err)
+			}
+		}()
+
+		// Metrics get a listener of their own so they can be kept off the
+		// public network; they are not served at all without metricsPort.
+		var metricsServer *http.Server
+		if config.Config.MetricsPort > 0 {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			metricsServer = &http.Server{
+				Addr:              fmt.Sprintf(":%d", config.Config.MetricsPort),
+				Handler:           mux,
+				ReadHeaderTimeout: seconds(serverConfig.ReadHeaderTimeoutSecond, 10),
+			}
+
+			metricsListener, err := net.Listen("tcp", metricsServer.Addr)
+			if err != nil {
+				panic(err)
+			}
+
+			go func() {
+				err := metricsServer.Serve(metricsListener)
+				if err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logrus.Fatalf("metrics server stopped: %v", err)
+				}
+			}()
+
+			logrus.Infof("serving metrics on %s", metricsServer.Addr)
+		}
+
+		health.SetReady(true)
+		logrus.Infof("listening on %s", server.Addr)
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+		<-ctx.Done()
+		stop()
+
+		// Fail readiness first and keep serving for a moment, so the load
+		// balancer stops sending new requests before the listener closes.
+		health.SetReady(false)
+		logrus.Info("shutting down")
+		time.Sleep(seconds(serverConfig.ShutdownDelaySecond, 5))
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), seconds(serverConfig.ShutdownTimeoutSecond, 20))
+		defer cancel()
+
+		err = server.Shutdown(shutdownCtx)
+		if err != nil {
+			logrus.Errorf("failed to drain connections: %v", err)
+		}
+
+		if metricsServer != nil {
+			err = metricsServer.Shutdown(shutdownCtx)
+			if err != nil {
+				logrus.Errorf("failed to stop metrics server: %v", err)
+			}
+		}
+
+		err = sqlDB.Close()
+		if err != nil {
+			logrus.Errorf("failed to close database: %v", err)
+		}
+
+		err = shutdownTracing(shutdownCtx)
+		if err != nil {
+			logrus.Errorf("failed to flush traces: %v", err)
+		}
+
+		logrus.Info("server stopped")
+	},
+}
+
+// seconds converts a configured number of seconds to a duration, using
+// fallback when the value is not set.
+func seconds(value int, fallback int) time.Duration {
+	if value <= 0 {
+		value = fallback
+	}
+
+	return time.Duration(value) * time.Second
+}
+
+func init() {
+	serveCommand.Flags().BoolVar(&autoMigrate, "migrate", false, "apply pending database migrations before serving")
+	serveCommand.Flags().BoolVar(&autoSeed, "seed", false, "run the seeders before serving")
+	rootCommand.AddCommand(serveCommand)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var tokenCommand = &cobra.Command{
+	Use:   "token",
+	Short: "Work with access tokens",
+}
+
+var tokenIssueFlags struct {
+	user string
+	ttl  time.Duration
+}
+
+var tokenIssueCommand = &cobra.Command{
+	Use:   "issue",
+	Short: "Issue an access token for a user, for debugging",
+	Long: "Issue an access token for a user without logging in. The token is signed with\n" +
+		"the configured signing keys, so it only validates against servers sharing them.",
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ttl := tokenIssueFlags.ttl
+		if ttl <= 0 {
+			return fmt.Errorf("ttl must be positive, got %s", ttl)
+		}
+
+		db, err := bootstrap()
+		if err != nil {
+			return err
+		}
+
+		_, service, err := newServices(db)
+		if err != nil {
+			return err
+		}
+
+		token, err := service.GetUser().IssueAccessToken(cmd.Context(), tokenIssueFlags.user, ttl)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(token.Token)
+		fmt.Printf("expires at %s\n", token.TokenExpiresAt.Format(time.RFC3339))
+
+		return nil
+	},
+}
+
+func init() {
+	tokenIssueCommand.Flags().StringVar(&tokenIssueFlags.user, "user", "", "UUID of the user")
+	tokenIssueCommand.Flags().DurationVar(&tokenIssueFlags.ttl, "ttl", 15*time.Minute, "token lifetime")
+	_ = tokenIssueCommand.MarkFlagRequired("user")
+
+	tokenCommand.AddCommand(tokenIssueCommand)
+	rootCommand.AddCommand(tokenCommand)
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"user-service/constants"
+	"user-service/domain/dto"
+
+	"github.com/spf13/cobra"
+
+	errCommon "user-service/common/error"
+)
+
+var userCommand = &cobra.Command{
+	Use:   "user",
+	Short: "Manage user accounts",
+}
+
+var userCreateFlags struct {
+	name     string
+	username string
+	email    string
+	phone    string
+	role     string
+	verified bool
+}
+
+var userCreateCommand = &cobra.Command{
+	Use:   "create",
+	Short: "Create a user account",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		flags := userCreateFlags
+
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		request := &dto.RegisterRequest{
+			Name:            flags.name,
+			Email:           flags.email,
+			Phone:           flags.phone,
+			Password:        password,
+			ConfirmPassword: password,
+		}
+		if flags.username != "" {
+			request.Username = &flags.username
+		}
+
+		err = errCommon.Validate(request)
+		if err != nil {
+			return err
+		}
+
+		db, err := bootstrap()
+		if err != nil {
+			return err
+		}
+
+		repository, service, err := newServices(db)
+		if err != nil {
+			return err
+		}
+
+		// Look the role up first so a typo does not leave a half-made account.
+		role, err := repository.GetRole().FindByCode(ctx, strings.ToUpper(flags.role))
+		if err != nil {
+			return err
+		}
+
+		result, err := service.GetUser().Register(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		uuid := result.User.UUID.String()
+		if result.User.Role != strings.ToLower(role.Code) {
+			_, err = service.GetUser().UpdateRole(ctx, uuid, &dto.UpdateUserRoleRequest{RoleID: role.ID})
+			if err != nil {
+				return err
+			}
+		}
+
+		if flags.verified {
+			now := time.Now()
+			err = repository.GetUser().SetEmailVerifiedAt(ctx, uuid, &now)
+			if err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("created %s user %s\n", strings.ToLower(role.Code), uuid)
+
+		return nil
+	},
+}
+
+var userSetPasswordCommand = &cobra.Command{
+	Use:   "set-password UUID",
+	Short: "Set a user's password and end their sessions",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		db, err := bootstrap()
+		if err != nil {
+			return err
+		}
+
+		_, service, err := newServices(db)
+		if err != nil {
+			return err
+		}
+
+		err = service.GetUser().SetPassword(cmd.Context(), args[0], password)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("password of %s updated\n", args[0])
+
+		return nil
+	},
+}
+
+// readPassword reads the password from the first line of stdin. Passwords are
+// never taken as flags, which would leave them in shell history and in the
+// process list.
+func readPassword(cmd *cobra.Command) (string, error) {
+	fromStdin, err := cmd.Flags().GetBool("password-stdin")
+	if err != nil {
+		return "", err
+	}
+	if !fromStdin {
+		return "", errors.New("the password must be passed on stdin with --password-stdin")
+	}
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("no password on stdin")
+	}
+
+	return password, nil
+}
+
+var userListFlags dto.UserListRequest
+
+var userListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List user accounts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		request := userListFlags
+
+		err := errCommon.Validate(&request)
+		if err != nil {
+			return err
+		}
+
+		db, err := bootstrap()
+		if err != nil {
+			return err
+		}
+
+		_, service, err := newServices(db)
+		if err != nil {
+			return err
+		}
+
+		users, meta, err := service.GetUser().ListUsers(cmd.Context(), &request)
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "UUID\tNAME\tEMAIL\tPHONE\tROLE\tSTATUS")
+		for _, user := range users {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", user.UUID, user.Name, user.Email, user.Phone, user.Role, user.Status)
+		}
+		writer.Flush()
+
+		if meta.Total != nil {
+			fmt.Printf("page %d of %d, %d users\n", meta.Page, *meta.TotalPages, *meta.Total)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	flags := userCreateCommand.Flags()
+	flags.StringVar(&userCreateFlags.name, "name", "", "full name")
+	flags.StringVar(&userCreateFlags.username, "username", "", "optional username")
+	flags.StringVar(&userCreateFlags.email, "email", "", "email address")
+	flags.StringVar(&userCreateFlags.phone, "phone", "", "phone number")
+	flags.Bool("password-stdin", false, "read the initial password from stdin")
+	flags.StringVar(&userCreateFlags.role, "role", strings.ToLower(constants.CustomerCode), "role code")
+	flags.BoolVar(&userCreateFlags.verified, "verified", false, "mark the email address as verified")
+	_ = userCreateCommand.MarkFlagRequired("name")
+	_ = userCreateCommand.MarkFlagRequired("email")
+	_ = userCreateCommand.MarkFlagRequired("phone")
+	_ = userCreateCommand.MarkFlagRequired("password-stdin")
+
+	userSetPasswordCommand.Flags().Bool("password-stdin", false, "read the new password from stdin")
+	_ = userSetPasswordCommand.MarkFlagRequired("password-stdin")
+
+	flags = userListCommand.Flags()
+	flags.IntVar(&userListFlags.Page, "page", 1, "page number")
+	flags.IntVar(&userListFlags.Limit, "limit", 20, "users per page")
+	flags.StringVar(&userListFlags.Role, "role", "", "only users with this role code")
+	flags.StringVar(&userListFlags.Status, "status", "", "only users with this status")
+	flags.StringVar(&userListFlags.Search, "search", "", "match name, email, phone or username")
+
+	userCommand.AddCommand(userCreateCommand, userSetPasswordCommand, userListCommand)
+	rootCommand.AddCommand(userCommand)
+}
@@ -1,66 +1,33 @@
 package error
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"strings"
+	"user-service/common/logger"
 
-	"github.com/go-playground/validator/v10"
-	"github.com/sirupsen/logrus"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	constantErr "user-service/constants/error"
 )
 
-type ValidationResponse struct {
-	Field   string `json:"field,omitempty"`
-	Message string `json:"message,omitempty"`
+// WrapSQLError logs the database error behind a failed query and returns it
+// wrapped in ErrSQLError, whose message carries no SQL detail to the client.
+func WrapSQLError(ctx context.Context, err error) error {
+	logger.FromContext(ctx).WithError(err).Error("database query failed")
+
+	return constantErr.ErrSQLError.Wrap(err)
 }
 
-var ErrValidator = map[string]string{}
+// uniqueViolation is the Postgres SQLSTATE for a unique constraint violation.
+const uniqueViolation = "23505"
 
-func ErrValidationResponse(err error) (validationResponse []ValidationResponse) {
-	var fieldErrors validator.ValidationErrors
-	if errors.As(err, &fieldErrors) {
-		for _, err := range fieldErrors {
-			switch err.Tag() {
-			case "required":
-				validationResponse = append(validationResponse, ValidationResponse{
-					Field:   err.Field(),
-					Message: fmt.Sprintf("%s is required", err.Field()),
-				})
-			case "email":
-				validationResponse = append(validationResponse, ValidationResponse{
-					Field:   err.Field(),
-					Message: fmt.Sprintf("%s is not valid email address", err.Field()),
-				})
-			default:
-				errValidator, ok := ErrValidator[err.Tag()]
-				if ok {
-					count := strings.Count(errValidator, "%s")
-					if count == 1 {
-						validationResponse = append(validationResponse, ValidationResponse{
-							Field:   err.Field(),
-							Message: fmt.Sprintf(errValidator, err.Field()),
-						})
-					} else {
-						validationResponse = append(validationResponse, ValidationResponse{
-							Field:   err.Field(),
-							Message: fmt.Sprintf(errValidator, err.Field(), err.Param()),
-						})
-					}
-				} else {
-					validationResponse = append(validationResponse, ValidationResponse{
-						Field:   err.Field(),
-						Message: fmt.Sprintf("something wrong on %s: %s", err.Field(), err.Tag()),
-					})
-				}
-			}
-		}
+// UniqueConstraint returns the name of the unique index or constraint err
+// violated, or an empty string when err is not a unique violation.
+func UniqueConstraint(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return pgErr.ConstraintName
 	}
 
-	return validationResponse
-}
-
-func WrapError(err error) error {
-	logrus.Errorf("error: %v", err)
-
-	return err
+	return ""
 }
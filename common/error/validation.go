@@ -0,0 +1,165 @@
+package error
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"user-service/common/locale"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ValidationResponse struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ErrValidator holds the message for every validator tag used by the request
+// DTOs, per language. A message takes the field name and, when it has a second
+// %s, the tag parameter. min, max and len have variants for numbers
+// ("_number") and lists ("_items"); "default" covers any other tag.
+var ErrValidator = map[string]map[string]string{
+	locale.English: {
+		"required":         "%s is required",
+		"required_without": "%s is required when %s is not provided",
+		"excluded_with":    "%s cannot be combined with %s",
+		"email":            "%s is not valid email address",
+		"numeric":          "%s must contain only digits",
+		"uppercase":        "%s must be uppercase",
+		"username":         "%s must contain at least one letter",
+		"oneof":            "%s must be one of: %s",
+		"excludesall":      "%s must not contain any of: %s",
+		"len":              "%s must be exactly %s characters",
+		"min":              "%s must be at least %s characters",
+		"min_number":       "%s must be %s or greater",
+		"min_items":        "%s must contain at least %s items",
+		"max":              "%s must be at most %s characters",
+		"max_number":       "%s must be %s or less",
+		"max_items":        "%s must contain at most %s items",
+		"default":          "%s is invalid",
+	},
+	locale.Indonesian: {
+		"required":         "%s wajib diisi",
+		"required_without": "%s wajib diisi jika %s tidak diisi",
+		"excluded_with":    "%s tidak dapat digunakan bersama %s",
+		"email":            "%s bukan alamat email yang valid",
+		"numeric":          "%s hanya boleh berisi angka",
+		"uppercase":        "%s harus menggunakan huruf kapital",
+		"username":         "%s harus mengandung setidaknya satu huruf",
+		"oneof":            "%s harus salah satu dari: %s",
+		"excludesall":      "%s tidak boleh mengandung: %s",
+		"len":              "%s harus tepat %s karakter",
+		"min":              "%s minimal %s karakter",
+		"min_number":       "%s minimal bernilai %s",
+		"min_items":        "%s minimal berisi %s item",
+		"max":              "%s maksimal %s karakter",
+		"max_number":       "%s maksimal bernilai %s",
+		"max_items":        "%s maksimal berisi %s item",
+		"default":          "%s tidak valid",
+	},
+}
+
+var validate = newValidator()
+
+// newValidator reports fields under the names clients send them as: the JSON
+// key for bodies and the form key for query strings.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, key := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+
+		return field.Name
+	})
+
+	// Login treats identifiers without letters as phone numbers, so a
+	// username needs at least one letter to be usable.
+	v.RegisterValidation("username", func(field validator.FieldLevel) bool {
+		return strings.ContainsFunc(field.Field().String(), unicode.IsLetter)
+	})
+
+	return v
+}
+
+// Validate checks request against its validate tags.
+func Validate(request any) error {
+	return validate.Struct(request)
+}
+
+// ErrValidationResponse renders the field errors in err as messages in lang,
+// falling back to the default language when lang has no translations.
+func ErrValidationResponse(err error, lang string) (validationResponse []ValidationResponse) {
+	messages, ok := ErrValidator[lang]
+	if !ok {
+		messages = ErrValidator[locale.Default]
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		for _, err := range fieldErrors {
+			message, ok := messages[messageKey(err)]
+			if !ok {
+				message = messages["default"]
+			}
+
+			if strings.Count(message, "%s") == 1 {
+				message = fmt.Sprintf(message, err.Field())
+			} else {
+				message = fmt.Sprintf(message, err.Field(), messageParam(err))
+			}
+
+			validationResponse = append(validationResponse, ValidationResponse{
+				Field:   err.Field(),
+				Message: message,
+			})
+		}
+	}
+
+	return validationResponse
+}
+
+func messageKey(err validator.FieldError) string {
+	switch err.Tag() {
+	case "min", "max", "len":
+		switch err.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return err.Tag() + "_items"
+		case reflect.String:
+			return err.Tag()
+		default:
+			return err.Tag() + "_number"
+		}
+	}
+
+	return err.Tag()
+}
+
+func messageParam(err validator.FieldError) string {
+	switch err.Tag() {
+	case "oneof":
+		return strings.Join(strings.Fields(err.Param()), ", ")
+	case "excludesall":
+		chars := make([]string, 0, len(err.Param()))
+		for _, char := range err.Param() {
+			chars = append(chars, fmt.Sprintf("%q", char))
+		}
+		return strings.Join(chars, ", ")
+	case "required_without", "excluded_with":
+		// The parameter names the other Go field; the DTOs use its
+		// lower camel case form as the JSON key.
+		first, size := utf8.DecodeRuneInString(err.Param())
+		return string(unicode.ToLower(first)) + err.Param()[size:]
+	}
+
+	return err.Param()
+}
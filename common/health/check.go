@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"time"
+	"user-service/config"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+const pingTimeout = 2 * time.Second
+
+type Check struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+	Details   any     `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status string  `json:"status"`
+	Checks []Check `json:"checks"`
+}
+
+type PoolStats struct {
+	MaxOpenConnections int     `json:"maxOpenConnections"`
+	OpenConnections    int     `json:"openConnections"`
+	InUse              int     `json:"inUse"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"waitCount"`
+	WaitDurationMs     float64 `json:"waitDurationMs"`
+}
+
+type Checker struct {
+	db *gorm.DB
+}
+
+type IChecker interface {
+	Readiness(context.Context) Report
+}
+
+func NewChecker(db *gorm.DB) IChecker {
+	return &Checker{db: db}
+}
+
+// Readiness runs every dependency check. The report is up only when all of
+// them are.
+func (c *Checker) Readiness(ctx context.Context) Report {
+	checks := []Check{
+		run("server", func() (any, error) {
+			if !IsReady() {
+				return nil, errShuttingDown
+			}
+			return nil, nil
+		}),
+		run("database", func() (any, error) {
+			return c.pingDatabase(ctx)
+		}),
+		run("config", func() (any, error) {
+			if config.Source == "" {
+				return nil, errConfigNotLoaded
+			}
+			return map[string]string{"source": config.Source}, nil
+		}),
+	}
+
+	report := Report{Status: StatusUp, Checks: checks}
+	for _, check := range checks {
+		if check.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) pingDatabase(ctx context.Context) (any, error) {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	stats := sqlDB.Stats()
+	details := PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     milliseconds(stats.WaitDuration),
+	}
+
+	return details, sqlDB.PingContext(ctx)
+}
+
+func run(name string, fn func() (any, error)) Check {
+	start := time.Now()
+	details, err := fn()
+
+	check := Check{
+		Name:      name,
+		Status:    StatusUp,
+		LatencyMs: milliseconds(time.Since(start)),
+		Details:   details,
+	}
+	if err != nil {
+		check.Status = StatusDown
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
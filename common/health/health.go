@@ -0,0 +1,24 @@
+package health
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+var ready atomic.Bool
+
+var (
+	errShuttingDown    = errors.New("server is shutting down")
+	errConfigNotLoaded = errors.New("configuration is not loaded")
+)
+
+// SetReady flips the readiness reported to the orchestrator. The server turns
+// it on once it accepts connections and off as soon as shutdown starts, so
+// traffic is routed away before connections are drained.
+func SetReady(value bool) {
+	ready.Store(value)
+}
+
+func IsReady() bool {
+	return ready.Load()
+}
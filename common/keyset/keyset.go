@@ -0,0 +1,254 @@
+// Package keyset holds the asymmetric keys used to sign and verify access
+// tokens and publishes their public halves as a JWKS document.
+//
+// Rotating a key is a two step rollout: first add the new key to
+// jwtSigningKeys and deploy, so every replica can verify it, then point
+// jwtActiveKeyId at it and set retiredAt on the previous key. A retired key is
+// kept for verification until retiredAt plus jwtExpirationTime, after which
+// every token it signed has expired and it is dropped from the set.
+package keyset
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+	"user-service/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+
+	errConstants "user-service/constants/error"
+)
+
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.Signer
+	RetiredAt  *time.Time
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+var (
+	mu        sync.RWMutex
+	keys      = map[string]*Key{}
+	activeKey *Key
+)
+
+func ephemeralKeyAllowed() bool {
+	return config.Config.JwtAllowEphemeralKey || config.Config.IsDevelopment()
+}
+
+// Init loads the keys listed in the configuration. Keys are required, except
+// in local and dev environments or when jwtAllowEphemeralKey is set: there an
+// ephemeral Ed25519 key is generated instead, whose tokens do not survive a
+// restart and cannot be shared between replicas.
+func Init() error {
+	loaded := map[string]*Key{}
+	for _, item := range config.Config.JwtSigningKeys {
+		key, err := loadKey(item)
+		if err != nil {
+			return fmt.Errorf("jwt signing key %q: %w", item.Kid, err)
+		}
+		loaded[key.ID] = key
+	}
+
+	if len(loaded) == 0 {
+		if !ephemeralKeyAllowed() {
+			return errors.New("no jwt signing keys configured; set jwtSigningKeys, or jwtAllowEphemeralKey for a single throwaway instance")
+		}
+
+		logrus.Warn("no jwt signing keys configured, generating an ephemeral key")
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+
+		key := &Key{ID: "ephemeral", Method: jwt.SigningMethodEdDSA, PrivateKey: privateKey}
+		loaded[key.ID] = key
+		config.Config.JwtActiveKeyID = key.ID
+	}
+
+	active, ok := loaded[config.Config.JwtActiveKeyID]
+	if !ok {
+		return fmt.Errorf("active jwt signing key %q is not configured", config.Config.JwtActiveKeyID)
+	}
+
+	if active.RetiredAt != nil {
+		return fmt.Errorf("active jwt signing key %q is retired", active.ID)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	keys = loaded
+	activeKey = active
+
+	return nil
+}
+
+// Sign signs the claims with the active key and stamps its kid in the header.
+func Sign(claims jwt.Claims) (string, error) {
+	mu.RLock()
+	key := activeKey
+	mu.RUnlock()
+
+	if key == nil {
+		return "", errors.New("keyset is not initialised")
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.PrivateKey)
+}
+
+// Keyfunc resolves the verification key from the kid header. It is meant to be
+// passed to jwt.Parse and rejects tokens whose alg does not match the key.
+func Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	key, ok := lookup(kid)
+	if !ok {
+		return nil, errConstants.ErrInvalidToken
+	}
+
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, errConstants.ErrInvalidToken
+	}
+
+	return key.PrivateKey.Public(), nil
+}
+
+// ValidMethods lists the algorithms accepted by Keyfunc, for jwt.WithValidMethods.
+func ValidMethods() []string {
+	return []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+}
+
+func JWKS() JSONWebKeySet {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keys {
+		if !usable(key) {
+			continue
+		}
+
+		jwk := JSONWebKey{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch publicKey := key.PrivateKey.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func lookup(kid string) (*Key, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	key, ok := keys[kid]
+	if !ok || !usable(key) {
+		return nil, false
+	}
+
+	return key, true
+}
+
+// usable reports whether tokens signed by the key may still be valid.
+func usable(key *Key) bool {
+	if key.RetiredAt == nil {
+		return true
+	}
+
+	grace := time.Duration(config.Config.JwtExpirationTime) * time.Minute
+
+	return time.Now().Before(key.RetiredAt.Add(grace))
+}
+
+func loadKey(item config.JwtSigningKey) (*Key, error) {
+	if item.Kid == "" {
+		return nil, errors.New("kid is required")
+	}
+
+	data := []byte(item.PrivateKey)
+	if item.PrivateKeyPath != "" {
+		content, err := os.ReadFile(item.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	var (
+		privateKey any
+		err        error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{ID: item.Kid}
+	switch privateKey := privateKey.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PrivateKey = privateKey
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PrivateKey = privateKey
+	default:
+		return nil, errors.New("only RSA and Ed25519 keys are supported")
+	}
+
+	if item.RetiredAt != "" {
+		retiredAt, err := time.Parse(time.RFC3339, item.RetiredAt)
+		if err != nil {
+			return nil, fmt.Errorf("retiredAt: %w", err)
+		}
+		key.RetiredAt = &retiredAt
+	}
+
+	return key, nil
+}
@@ -0,0 +1,89 @@
+package locale
+
+import errConstant "user-service/constants/error"
+
+// catalog translates the messages of the error codes in constants/error. The
+// English text is the message the error itself carries, so only other
+// languages are listed here.
+var catalog = map[string]map[string]string{
+	Indonesian: {
+		errConstant.ErrInternalServerError.Code: "terjadi kesalahan pada server",
+		errConstant.ErrSQLError.Code:            "terjadi kesalahan pada server",
+		errConstant.ErrToManyRequest.Code:       "terlalu banyak permintaan",
+		errConstant.ErrUnauthorize.Code:         "tidak terautentikasi",
+		errConstant.ErrInvalidToken.Code:        "token tidak valid",
+		errConstant.ErrForbidden.Code:           "akses ditolak",
+		errConstant.ErrInvalidCursor.Code:       "cursor tidak valid",
+		errConstant.ErrBadRequest.Code:          "permintaan tidak valid",
+		errConstant.ErrNotFound.Code:            "tidak ditemukan",
+		errConstant.ErrRouteNotFound.Code:       "Path tidak ditemukan",
+		errConstant.ErrValidationFailed.Code:    "validasi gagal",
+		errConstant.ErrRequestTooLarge.Code:     "ukuran body permintaan terlalu besar",
+
+		errConstant.ErrUserNotFound.Code:      "pengguna tidak ditemukan",
+		errConstant.ErrPasswordIncorrect.Code: "kata sandi salah",
+		errConstant.ErrUsernameExists.Code:    "username sudah digunakan",
+		errConstant.ErrEmailExists.Code:       "email sudah digunakan",
+		errConstant.ErrPhoneExists.Code:       "nomor telepon sudah digunakan",
+		errConstant.ErrPasswordDoesMatch.Code: "konfirmasi kata sandi tidak cocok",
+		errConstant.ErrEmailNotVerified.Code:  "alamat email belum diverifikasi",
+		errConstant.ErrAccountLocked.Code:     "akun dikunci sementara",
+		errConstant.ErrAccountSuspended.Code:  "akun ditangguhkan",
+		errConstant.ErrAccountClosed.Code:     "akun telah dinonaktifkan",
+		errConstant.ErrAmbiguousLogin.Code:    "identitas cocok dengan lebih dari satu akun, masuk dengan email atau username",
+		errConstant.ErrLastAdmin.Code:         "administrator aktif terakhir tidak dapat ditangguhkan, dinonaktifkan, atau dihapus",
+
+		errConstant.ErrInvalidRefreshToken.Code:      "refresh token tidak valid",
+		errConstant.ErrRefreshTokenExpired.Code:      "refresh token sudah kedaluwarsa",
+		errConstant.ErrRefreshTokenReused.Code:       "penggunaan ulang refresh token terdeteksi",
+		errConstant.ErrTokenRevoked.Code:             "token telah dicabut",
+		errConstant.ErrInvalidResetToken.Code:        "token reset kata sandi tidak valid",
+		errConstant.ErrResetTokenExpired.Code:        "token reset kata sandi sudah kedaluwarsa",
+		errConstant.ErrInvalidVerificationToken.Code: "token verifikasi email tidak valid",
+		errConstant.ErrVerificationTokenExpired.Code: "token verifikasi email sudah kedaluwarsa",
+		errConstant.ErrInvalidTwoFactorCode.Code:     "kode verifikasi dua langkah tidak valid",
+		errConstant.ErrInvalidChallengeToken.Code:    "tantangan verifikasi dua langkah tidak valid atau sudah kedaluwarsa",
+		errConstant.ErrTwoFactorNotSetup.Code:        "verifikasi dua langkah belum diatur",
+		errConstant.ErrTwoFactorAlreadyEnabled.Code:  "verifikasi dua langkah sudah aktif",
+
+		errConstant.ErrRoleNotFound.Code:       "role tidak ditemukan",
+		errConstant.ErrRoleExists.Code:         "role sudah ada",
+		errConstant.ErrRoleProtected.Code:      "role sistem tidak dapat diubah atau dihapus",
+		errConstant.ErrRoleInUse.Code:          "role masih digunakan oleh pengguna",
+		errConstant.ErrPermissionNotFound.Code: "permission tidak ditemukan",
+	},
+}
+
+// Message returns the message of err in lang, or its own message when there
+// is no translation.
+func Message(lang string, err *errConstant.AppError) string {
+	message, ok := catalog[lang][err.Code]
+	if !ok {
+		return err.Message
+	}
+
+	return message
+}
+
+// Messages of successful responses, keyed by their English text.
+const (
+	TextOK      = "OK"
+	TextWelcome = "wellcome to user service"
+)
+
+var texts = map[string]map[string]string{
+	Indonesian: {
+		TextOK:      "Berhasil",
+		TextWelcome: "selamat datang di user service",
+	},
+}
+
+// Text returns text in lang, or text itself when there is no translation.
+func Text(lang, text string) string {
+	translated, ok := texts[lang][text]
+	if !ok {
+		return text
+	}
+
+	return translated
+}
@@ -0,0 +1,69 @@
+package locale
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	English    = "en"
+	Indonesian = "id"
+
+	Default = English
+)
+
+var supported = []string{English, Indonesian}
+
+// Parse picks the supported language the Accept-Language header value
+// prefers most, going by quality values and falling back to Default. Region
+// subtags are ignored, so "id-ID" selects Indonesian.
+func Parse(acceptLanguage string) string {
+	best, bestQuality := Default, 0.0
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !Supported(primary) {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = primary, quality
+		}
+	}
+
+	return best
+}
+
+func Supported(lang string) bool {
+	return slices.Contains(supported, lang)
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx that carries lang as the language of the
+// request.
+func WithContext(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language negotiated for the request, or Default.
+func FromContext(ctx context.Context) string {
+	lang, ok := ctx.Value(contextKey{}).(string)
+	if !ok {
+		return Default
+	}
+
+	return lang
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"time"
+	"user-service/common/tracing"
+	"user-service/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+type contextKey struct{}
+
+var base = logrus.NewEntry(logrus.StandardLogger())
+
+// Init switches logging to JSON and tags every entry with the service name.
+// It must run after config.Init.
+func Init() {
+	logrus.SetFormatter(&logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano})
+	base = logrus.WithField("service", config.Config.AppName)
+}
+
+// WithContext returns a copy of ctx carrying entry.
+func WithContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, entry)
+}
+
+// With returns a copy of ctx whose logger has the extra field.
+func With(ctx context.Context, key string, value any) context.Context {
+	return WithContext(ctx, entryFromContext(ctx).WithField(key, value))
+}
+
+// FromContext returns the logger carried by ctx, falling back to the service
+// logger, with the trace ID of ctx attached when there is one.
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := entryFromContext(ctx)
+
+	traceID := tracing.TraceID(ctx)
+	if traceID != "" {
+		entry = entry.WithField("trace_id", traceID)
+	}
+
+	return entry.WithContext(ctx)
+}
+
+func entryFromContext(ctx context.Context) *logrus.Entry {
+	entry, ok := ctx.Value(contextKey{}).(*logrus.Entry)
+	if !ok {
+		return base
+	}
+
+	return entry
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	errConstants "user-service/constants/error"
+)
+
+const namespace = "user_service"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	LoginAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_attempts_total",
+		Help:      "Login attempts by result: success, challenge, failure, lockout or rejected.",
+	}, []string{"result"})
+
+	Registrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "registrations_total",
+		Help:      "Accounts registered.",
+	})
+
+	TokenValidationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "token_validation_failures_total",
+		Help:      "Requests rejected by Authenticate, by reason.",
+	}, []string{"reason"})
+
+	RateLimited = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by the rate limiter.",
+	})
+)
+
+// Token validation failure reasons.
+const (
+	ReasonMissing   = "missing"
+	ReasonMalformed = "malformed"
+	ReasonInvalid   = "invalid"
+	ReasonRevoked   = "revoked"
+	ReasonInactive  = "inactive"
+	ReasonAPIKey    = "api_key"
+)
+
+// RegisterDB exports the connection pool statistics of db, labelled with the
+// database name.
+func RegisterDB(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveLogin counts the outcome of a password or two-factor login step.
+// challenge reports that a second factor is still required.
+func ObserveLogin(err error, challenge bool) {
+	LoginAttempts.WithLabelValues(loginResult(err, challenge)).Inc()
+}
+
+func loginResult(err error, challenge bool) string {
+	switch {
+	case err == nil && challenge:
+		return "challenge"
+	case err == nil:
+		return "success"
+	case errors.Is(err, errConstants.ErrAccountLocked), errors.Is(err, errConstants.ErrToManyRequest):
+		return "lockout"
+	case errors.Is(err, errConstants.ErrUserNotFound),
+		errors.Is(err, errConstants.ErrPasswordIncorrect),
+		errors.Is(err, errConstants.ErrInvalidTwoFactorCode):
+		return "failure"
+	default:
+		return "rejected"
+	}
+}
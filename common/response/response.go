@@ -1,7 +1,7 @@
 package response
 
 import (
-	"net/http"
+	"user-service/common/locale"
 	"user-service/constants"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +13,10 @@ type Response struct {
 	Status  string      `json:"status"`
 	Message any         `json:"message"`
 	Data    interface{} `json:"data"`
+	Meta    interface{} `json:"meta,omitempty"`
 	Token   *string     `json:"token,omitempty"`
+	// ErrorCode is the stable code of the error behind a failed response.
+	ErrorCode string `json:"error_code,omitempty"`
 }
 
 type ParamHTTPResp struct {
@@ -22,6 +25,7 @@ type ParamHTTPResp struct {
 	Message *string
 	Gin     *gin.Context
 	Data    interface{}
+	Meta    interface{}
 	Token   *string
 }
 
@@ -29,28 +33,27 @@ func HttpResponse(param ParamHTTPResp) {
 	if param.Err == nil {
 		param.Gin.JSON(param.Code, Response{
 			Status:  constants.Success,
-			Message: http.StatusText(http.StatusOK),
+			Message: locale.Text(locale.FromContext(param.Gin.Request.Context()), locale.TextOK),
 			Data:    param.Data,
+			Meta:    param.Meta,
 			Token:   param.Token,
 		})
 
 		return
 	}
 
-	message := errConstant.ErrInternalServerError.Error()
+	// Typed errors decide the status themselves; Code only applies to errors
+	// that carry none, such as binding and validation failures.
+	appErr := errConstant.As(param.Err, param.Code)
+	message := locale.Message(locale.FromContext(param.Gin.Request.Context()), appErr)
 	if param.Message != nil {
 		message = *param.Message
-	} else if param.Err != nil {
-		if errConstant.ErrMapping(param.Err) {
-			message = param.Err.Error()
-		}
 	}
 
-	param.Gin.JSON(param.Code, Response{
-		Status:  constants.Error,
-		Message: message,
-		Data:    param.Data,
+	param.Gin.JSON(appErr.Status, Response{
+		Status:    constants.Error,
+		Message:   message,
+		Data:      param.Data,
+		ErrorCode: appErr.Code,
 	})
-
-	return
 }
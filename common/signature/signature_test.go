@@ -0,0 +1,66 @@
+package signature
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	const key = "secret"
+	signed := Request{
+		ServiceName: "order-service",
+		Method:      "POST",
+		Path:        "/api/v1/users?page=1",
+		Body:        []byte(`{"name":"a"}`),
+		Timestamp:   "1700000000",
+		Nonce:       "n-1",
+	}
+	signature := Sign(key, signed)
+
+	tests := []struct {
+		name    string
+		key     string
+		request func(Request) Request
+		sig     string
+		want    bool
+	}{
+		{name: "valid", want: true},
+		{name: "upper case signature", sig: strings.ToUpper(signature), want: true},
+		{name: "lower case method", request: func(r Request) Request { r.Method = "post"; return r }, want: true},
+		{name: "wrong key", key: "other", want: false},
+		{name: "tampered service name", request: func(r Request) Request { r.ServiceName = "evil-service"; return r }},
+		{name: "tampered method", request: func(r Request) Request { r.Method = "DELETE"; return r }},
+		{name: "tampered path", request: func(r Request) Request { r.Path = "/api/v1/users/1"; return r }},
+		{name: "tampered query", request: func(r Request) Request { r.Path = "/api/v1/users?page=2"; return r }},
+		{name: "tampered body", request: func(r Request) Request { r.Body = []byte(`{"name":"b"}`); return r }},
+		{name: "empty body", request: func(r Request) Request { r.Body = nil; return r }},
+		{name: "tampered timestamp", request: func(r Request) Request { r.Timestamp = "1700000001"; return r }},
+		{name: "tampered nonce", request: func(r Request) Request { r.Nonce = "n-2"; return r }},
+		{name: "empty signature", sig: "-", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := signed
+			if tt.request != nil {
+				request = tt.request(request)
+			}
+
+			key := key
+			if tt.key != "" {
+				key = tt.key
+			}
+
+			sig := signature
+			if tt.sig == "-" {
+				sig = ""
+			} else if tt.sig != "" {
+				sig = tt.sig
+			}
+
+			if got := Verify(key, request, sig); got != tt.want {
+				t.Errorf("Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
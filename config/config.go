@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"slices"
+	"strings"
 	"user-service/common/util"
 
 	"github.com/sirupsen/logrus"
@@ -10,16 +12,84 @@ import (
 
 var Config AppConfig
 
+const (
+	SourceFile   = "file"
+	SourceConsul = "consul"
+)
+
+// Source records where Config was loaded from; it stays empty until Init
+// succeeds.
+var Source string
+
 type AppConfig struct {
-	Port                  int      `json:"port"`
-	AppName               string   `json:"appName"`
-	AppEnv                string   `json:"appEnv"`
-	SignatureKey          string   `json:"signatureKey"`
-	Database              Database `json:"database"`
-	RateLimiterMaxRequest float64  `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond int      `json:"rateLimiterTimeSecond"`
-	JwtSecretKey          string   `json:"jwtSecretKey"`
-	JwtExpirationTime     int      `json:"jwtExpirationTime"`
+	Port                            int             `json:"port"`
+	MetricsPort                     int             `json:"metricsPort"`
+	AppName                         string          `json:"appName"`
+	AppEnv                          string          `json:"appEnv"`
+	SignatureKey                    string          `json:"signatureKey"`
+	SignatureMaxBodyBytes           int64           `json:"signatureMaxBodyBytes"`
+	SignatureMaxSkewSecond          int             `json:"signatureMaxSkewSecond"`
+	Database                        Database        `json:"database"`
+	RateLimiterMaxRequest           float64         `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond           int             `json:"rateLimiterTimeSecond"`
+	JwtSigningKeys                  []JwtSigningKey `json:"jwtSigningKeys"`
+	JwtAllowEphemeralKey            bool            `json:"jwtAllowEphemeralKey"`
+	JwtActiveKeyID                  string          `json:"jwtActiveKeyId"`
+	JwtExpirationTime               int             `json:"jwtExpirationTime"`
+	RefreshTokenExpirationTime      int             `json:"refreshTokenExpirationTime"`
+	RevocationCacheTTLSecond        int             `json:"revocationCacheTTLSecond"`
+	PasswordResetURL                string          `json:"passwordResetUrl"`
+	PasswordResetExpirationTime     int             `json:"passwordResetExpirationTime"`
+	EmailVerificationRequired       bool            `json:"emailVerificationRequired"`
+	EmailVerificationURL            string          `json:"emailVerificationUrl"`
+	EmailVerificationExpirationTime int             `json:"emailVerificationExpirationTime"`
+	Mail                            Mail            `json:"mail"`
+	TwoFactorChallengeTime          int             `json:"twoFactorChallengeTime"`
+	LoginThrottle                   LoginThrottle   `json:"loginThrottle"`
+	DefaultPhoneCountryCode         string          `json:"defaultPhoneCountryCode"`
+	HttpServer                      HttpServer      `json:"httpServer"`
+	Tracing                         Tracing         `json:"tracing"`
+}
+
+type Tracing struct {
+	Exporter    string  `json:"exporter"`
+	Endpoint    string  `json:"endpoint"`
+	Insecure    bool    `json:"insecure"`
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+type HttpServer struct {
+	ReadTimeoutSecond       int `json:"readTimeoutSecond"`
+	ReadHeaderTimeoutSecond int `json:"readHeaderTimeoutSecond"`
+	WriteTimeoutSecond      int `json:"writeTimeoutSecond"`
+	IdleTimeoutSecond       int `json:"idleTimeoutSecond"`
+	ShutdownDelaySecond     int `json:"shutdownDelaySecond"`
+	ShutdownTimeoutSecond   int `json:"shutdownTimeoutSecond"`
+}
+
+type LoginThrottle struct {
+	MaxAttempts       int `json:"maxAttempts"`
+	LockoutMinute     int `json:"lockoutMinute"`
+	BackoffBaseSecond int `json:"backoffBaseSecond"`
+	BackoffMaxSecond  int `json:"backoffMaxSecond"`
+	IPMaxAttempts     int `json:"ipMaxAttempts"`
+	IPWindowMinute    int `json:"ipWindowMinute"`
+}
+
+type Mail struct {
+	Driver   string `json:"driver"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+}
+
+type JwtSigningKey struct {
+	Kid            string `json:"kid"`
+	PrivateKey     string `json:"privateKey"`
+	PrivateKeyPath string `json:"privateKeyPath"`
+	RetiredAt      string `json:"retiredAt"`
 }
 
 type Database struct {
@@ -34,6 +104,15 @@ type Database struct {
 	MaxIdleTime           int    `json:"maxIdleTime"`
 }
 
+// developmentEnvironments are the app environments meant for local work,
+// where conveniences such as ephemeral keys and logged emails are allowed.
+var developmentEnvironments = []string{"local", "dev", "development"}
+
+// IsDevelopment reports whether AppEnv is one of the development environments.
+func (c *AppConfig) IsDevelopment() bool {
+	return slices.Contains(developmentEnvironments, strings.ToLower(c.AppEnv))
+}
+
 func Init() {
 	err := util.BindFromJSON(&Config, "config.json", ".")
 	if err != nil {
@@ -42,5 +121,8 @@ func Init() {
 		if err != nil {
 			panic(err)
 		}
+		Source = SourceConsul
+		return
 	}
+	Source = SourceFile
 }
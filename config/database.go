@@ -7,6 +7,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 func InitDatabase() (*gorm.DB, error) {
@@ -25,6 +26,15 @@ func InitDatabase() (*gorm.DB, error) {
 		return nil, err
 	}
 
+	err = db.Use(tracing.NewPlugin(
+		tracing.WithDBName(config.Database.Name),
+		tracing.WithoutQueryVariables(),
+		tracing.WithoutMetrics(),
+	))
+	if err != nil {
+		return nil, err
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
@@ -1,6 +1,13 @@
 package constants
 
 const (
-	UserLogin = "user_login"
-	Token     = "token"
+	UserLogin   = "user_login"
+	Token       = "token"
+	TokenClaims = "token_claims"
+)
+
+const (
+	IdentifierEmail    = "email"
+	IdentifierPhone    = "phone"
+	IdentifierUsername = "username"
 )
@@ -0,0 +1,76 @@
+package error
+
+import (
+	"errors"
+	"net/http"
+)
+
+// AppError is an error the API reports to clients. Code is a stable,
+// machine-readable identifier, Status the HTTP status it is answered with and
+// Message the text that is safe to show. Err optionally holds the underlying
+// cause, which is only ever logged.
+type AppError struct {
+	Code    string
+	Status  int
+	Message string
+	Err     error
+}
+
+func New(code string, status int, message string) *AppError {
+	return &AppError{Code: code, Status: status, Message: message}
+}
+
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an AppError with the same code, so an error
+// returned by Wrap still matches its sentinel under errors.Is.
+func (e *AppError) Is(target error) bool {
+	var appErr *AppError
+	if !errors.As(target, &appErr) {
+		return false
+	}
+
+	return e.Code == appErr.Code
+}
+
+// Wrap returns a copy of e that carries err as its cause.
+func (e *AppError) Wrap(err error) *AppError {
+	return &AppError{Code: e.Code, Status: e.Status, Message: e.Message, Err: err}
+}
+
+// As returns the AppError in the chain of err. Errors that are not typed are
+// reported as the generic error for fallbackStatus, or as an internal server
+// error when no fallback status is given.
+func As(err error, fallbackStatus int) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	switch fallbackStatus {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorize
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnprocessableEntity:
+		return ErrValidationFailed
+	case http.StatusTooManyRequests:
+		return ErrToManyRequest
+	default:
+		return ErrInternalServerError
+	}
+}
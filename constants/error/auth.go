@@ -0,0 +1,20 @@
+package error
+
+import "net/http"
+
+var (
+	ErrInvalidRefreshToken = New("INVALID_REFRESH_TOKEN", http.StatusUnauthorized, "invalid refresh token")
+	ErrRefreshTokenExpired = New("REFRESH_TOKEN_EXPIRED", http.StatusUnauthorized, "refresh token expired")
+	ErrRefreshTokenReused  = New("REFRESH_TOKEN_REUSED", http.StatusUnauthorized, "refresh token reuse detected")
+	ErrTokenRevoked        = New("TOKEN_REVOKED", http.StatusUnauthorized, "token has been revoked")
+	ErrInvalidResetToken   = New("INVALID_RESET_TOKEN", http.StatusBadRequest, "invalid password reset token")
+	ErrResetTokenExpired   = New("RESET_TOKEN_EXPIRED", http.StatusBadRequest, "password reset token expired")
+
+	ErrInvalidVerificationToken = New("INVALID_VERIFICATION_TOKEN", http.StatusBadRequest, "invalid email verification token")
+	ErrVerificationTokenExpired = New("VERIFICATION_TOKEN_EXPIRED", http.StatusBadRequest, "email verification token expired")
+
+	ErrInvalidTwoFactorCode    = New("INVALID_TWO_FACTOR_CODE", http.StatusUnauthorized, "invalid two-factor code")
+	ErrInvalidChallengeToken   = New("INVALID_CHALLENGE_TOKEN", http.StatusUnauthorized, "invalid or expired two-factor challenge")
+	ErrTwoFactorNotSetup       = New("TWO_FACTOR_NOT_SETUP", http.StatusBadRequest, "two-factor authentication is not set up")
+	ErrTwoFactorAlreadyEnabled = New("TWO_FACTOR_ALREADY_ENABLED", http.StatusConflict, "two-factor authentication is already enabled")
+)
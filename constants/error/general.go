@@ -1,21 +1,18 @@
 package error
 
-import "errors"
+import "net/http"
 
 var (
-	ErrInternalServerError = errors.New("internal server error")
-	ErrSQLError            = errors.New("database server failed to execute")
-	ErrToManyRequest       = errors.New("too many requests")
-	ErrUnauthorize         = errors.New("unauthorize")
-	ErrInvalidToken        = errors.New("invalid token")
-	ErrForbidden           = errors.New("forbidden")
+	ErrInternalServerError = New("INTERNAL_SERVER_ERROR", http.StatusInternalServerError, "internal server error")
+	ErrSQLError            = New("DATABASE_ERROR", http.StatusInternalServerError, "internal server error")
+	ErrToManyRequest       = New("TOO_MANY_REQUESTS", http.StatusTooManyRequests, "too many requests")
+	ErrUnauthorize         = New("UNAUTHORIZED", http.StatusUnauthorized, "unauthorize")
+	ErrInvalidToken        = New("INVALID_TOKEN", http.StatusUnauthorized, "invalid token")
+	ErrForbidden           = New("FORBIDDEN", http.StatusForbidden, "forbidden")
+	ErrInvalidCursor       = New("INVALID_CURSOR", http.StatusBadRequest, "invalid cursor")
+	ErrBadRequest          = New("BAD_REQUEST", http.StatusBadRequest, "bad request")
+	ErrNotFound            = New("NOT_FOUND", http.StatusNotFound, "not found")
+	ErrRouteNotFound       = New("ROUTE_NOT_FOUND", http.StatusNotFound, "Path Not Found")
+	ErrValidationFailed    = New("VALIDATION_FAILED", http.StatusUnprocessableEntity, "validation failed")
+	ErrRequestTooLarge     = New("REQUEST_TOO_LARGE", http.StatusRequestEntityTooLarge, "request body too large")
 )
-
-var GeneralErrors = []error{
-	ErrInternalServerError,
-	ErrSQLError,
-	ErrToManyRequest,
-	ErrUnauthorize,
-	ErrInvalidToken,
-	ErrForbidden,
-}
@@ -0,0 +1,11 @@
+package error
+
+import "net/http"
+
+var (
+	ErrRoleNotFound       = New("ROLE_NOT_FOUND", http.StatusNotFound, "role not found")
+	ErrRoleExists         = New("ROLE_EXISTS", http.StatusConflict, "role already exists")
+	ErrRoleProtected      = New("ROLE_PROTECTED", http.StatusForbidden, "system role cannot be changed or deleted")
+	ErrRoleInUse          = New("ROLE_IN_USE", http.StatusConflict, "role is still assigned to users")
+	ErrPermissionNotFound = New("PERMISSION_NOT_FOUND", http.StatusBadRequest, "permission not found")
+)
@@ -1,21 +1,18 @@
 package error
 
-import "errors"
+import "net/http"
 
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrPasswordIncorrect = errors.New("password incorrect")
-	ErrUsernameExists    = errors.New("username already exists")
-	ErrEmailExists       = errors.New("email already exists")
-	ErrPhoneExists       = errors.New("phone already exists")
-	ErrPasswordDoesMatch = errors.New("password does not match")
+	ErrUserNotFound      = New("USER_NOT_FOUND", http.StatusNotFound, "user not found")
+	ErrPasswordIncorrect = New("PASSWORD_INCORRECT", http.StatusUnauthorized, "password incorrect")
+	ErrUsernameExists    = New("USERNAME_EXISTS", http.StatusConflict, "username already exists")
+	ErrEmailExists       = New("EMAIL_EXISTS", http.StatusConflict, "email already exists")
+	ErrPhoneExists       = New("PHONE_EXISTS", http.StatusConflict, "phone already exists")
+	ErrPasswordDoesMatch = New("PASSWORD_MISMATCH", http.StatusBadRequest, "password does not match")
+	ErrEmailNotVerified  = New("EMAIL_NOT_VERIFIED", http.StatusForbidden, "email address is not verified")
+	ErrAccountLocked     = New("ACCOUNT_LOCKED", http.StatusTooManyRequests, "account is temporarily locked")
+	ErrAccountSuspended  = New("ACCOUNT_SUSPENDED", http.StatusForbidden, "account is suspended")
+	ErrAccountClosed     = New("ACCOUNT_CLOSED", http.StatusForbidden, "account is deactivated")
+	ErrAmbiguousLogin    = New("AMBIGUOUS_LOGIN", http.StatusConflict, "identifier matches more than one account, sign in with your email or username")
+	ErrLastAdmin         = New("LAST_ADMIN", http.StatusConflict, "the last active administrator cannot be suspended, deactivated or deleted")
 )
-
-var UserErrors = []error{
-	ErrUserNotFound,
-	ErrPasswordIncorrect,
-	ErrUsernameExists,
-	ErrEmailExists,
-	ErrPhoneExists,
-	ErrPasswordDoesMatch,
-}
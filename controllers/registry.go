@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	roleControllers "user-service/controllers/role"
 	controllers "user-service/controllers/user"
 	"user-service/services"
 )
@@ -11,6 +12,7 @@ type Registry struct {
 
 type IControllerRegistry interface {
 	GetUserController() controllers.IUserController
+	GetRoleController() roleControllers.IRoleController
 }
 
 func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistry {
@@ -20,3 +22,7 @@ func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistr
 func (r *Registry) GetUserController() controllers.IUserController {
 	return controllers.NewUserController(r.service)
 }
+
+func (r *Registry) GetRoleController() roleControllers.IRoleController {
+	return roleControllers.NewRoleController(r.service)
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"user-service/common/locale"
+	"user-service/common/response"
+	"user-service/domain/dto"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+
+	errCommon "user-service/common/error"
+	errConstants "user-service/constants/error"
+)
+
+type RoleController struct {
+	service services.IServiceRegistry
+}
+
+type IRoleController interface {
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	FindAll(ctx *gin.Context)
+	FindByID(ctx *gin.Context)
+}
+
+func NewRoleController(service services.IServiceRegistry) IRoleController {
+	return &RoleController{service: service}
+}
+
+func parseRoleID(ctx *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		return 0, errConstants.ErrRoleNotFound
+	}
+
+	return uint(id), nil
+}
+
+func bindRoleRequest(ctx *gin.Context) (*dto.RoleRequest, bool) {
+	request := &dto.RoleRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return nil, false
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return nil, false
+	}
+
+	return request, true
+}
+
+func (c *RoleController) Create(ctx *gin.Context) {
+	request, ok := bindRoleRequest(ctx)
+	if !ok {
+		return
+	}
+
+	role, err := c.service.GetRole().Create(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusCreated,
+		Data: role,
+		Gin:  ctx,
+	})
+}
+
+func (c *RoleController) Update(ctx *gin.Context) {
+	id, err := parseRoleID(ctx)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	request, ok := bindRoleRequest(ctx)
+	if !ok {
+		return
+	}
+
+	role, err := c.service.GetRole().Update(ctx.Request.Context(), id, request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: role,
+		Gin:  ctx,
+	})
+}
+
+func (c *RoleController) Delete(ctx *gin.Context) {
+	id, err := parseRoleID(ctx)
+	if err == nil {
+		err = c.service.GetRole().Delete(ctx.Request.Context(), id)
+	}
+
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *RoleController) FindAll(ctx *gin.Context) {
+	roles, err := c.service.GetRole().FindAll(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: roles,
+		Gin:  ctx,
+	})
+}
+
+func (c *RoleController) FindByID(ctx *gin.Context) {
+	id, err := parseRoleID(ctx)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	role, err := c.service.GetRole().FindByID(ctx.Request.Context(), id)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: role,
+		Gin:  ctx,
+	})
+}
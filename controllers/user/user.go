@@ -2,12 +2,13 @@ package controllers
 
 import (
 	"net/http"
+	"user-service/common/locale"
+	"user-service/common/metrics"
 	"user-service/common/response"
 	"user-service/domain/dto"
 	"user-service/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 
 	errCommon "user-service/common/error"
 )
@@ -18,6 +19,25 @@ type UserController struct {
 
 type IUserController interface {
 	Login(ctx *gin.Context)
+	RefreshToken(ctx *gin.Context)
+	Logout(ctx *gin.Context)
+	LogoutAll(ctx *gin.Context)
+	ForgotPassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
+	VerifyEmail(ctx *gin.Context)
+	ResendVerification(ctx *gin.Context)
+	SetupTwoFactor(ctx *gin.Context)
+	ConfirmTwoFactor(ctx *gin.Context)
+	EnrollTwoFactor(ctx *gin.Context)
+	VerifyTwoFactor(ctx *gin.Context)
+	DisableTwoFactor(ctx *gin.Context)
+	UnlockUser(ctx *gin.Context)
+	SuspendUser(ctx *gin.Context)
+	ReactivateUser(ctx *gin.Context)
+	DeleteUser(ctx *gin.Context)
+	CloseAccount(ctx *gin.Context)
+	ListUsers(ctx *gin.Context)
+	UpdateRole(ctx *gin.Context)
 	Register(ctx *gin.Context)
 	Update(ctx *gin.Context)
 	GetUserLogin(*gin.Context)
@@ -42,25 +62,446 @@ func (c *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
-	validate := validator.New()
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IPAddress = ctx.ClientIP()
+
+	user, err := c.service.GetUser().Login(ctx.Request.Context(), request)
+	metrics.ObserveLogin(err, user != nil && user.TwoFactor != nil)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	var token *string
+	if user.Token != "" {
+		token = &user.Token
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code:  http.StatusOK,
+		Data:  user,
+		Token: token,
+		Gin:   ctx,
+	})
+}
+
+func (c *UserController) RefreshToken(ctx *gin.Context) {
+	request := &dto.RefreshTokenRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IPAddress = ctx.ClientIP()
+
+	token, err := c.service.GetUser().RefreshToken(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnauthorized,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code:  http.StatusOK,
+		Data:  token,
+		Token: &token.Token,
+		Gin:   ctx,
+	})
+}
+
+func (c *UserController) Logout(ctx *gin.Context) {
+	err := c.service.GetUser().Logout(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) LogoutAll(ctx *gin.Context) {
+	err := c.service.GetUser().LogoutAll(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) ForgotPassword(ctx *gin.Context) {
+	request := &dto.ForgotPasswordRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = c.service.GetUser().ForgotPassword(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) ResetPassword(ctx *gin.Context) {
+	request := &dto.ResetPasswordRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = c.service.GetUser().ResetPassword(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) VerifyEmail(ctx *gin.Context) {
+	request := &dto.VerifyEmailRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = c.service.GetUser().VerifyEmail(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) ResendVerification(ctx *gin.Context) {
+	request := &dto.ResendVerificationRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = c.service.GetUser().ResendVerification(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) SetupTwoFactor(ctx *gin.Context) {
+	request := &dto.TwoFactorSetupRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	result, err := c.service.GetUser().SetupTwoFactor(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) ConfirmTwoFactor(ctx *gin.Context) {
+	request := &dto.TwoFactorConfirmRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	result, err := c.service.GetUser().ConfirmTwoFactor(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) EnrollTwoFactor(ctx *gin.Context) {
+	request := &dto.TwoFactorEnrollRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
 
-	err = validate.Struct(request)
+	err = errCommon.Validate(request)
 	if err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errCommon.WrapError(err)
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
 
+	result, err := c.service.GetUser().EnrollTwoFactor(ctx.Request.Context(), request)
+	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Err:     err,
-			Gin:     ctx,
+			Err: err,
+			Gin: ctx,
 		})
 
 		return
 	}
 
-	user, err := c.service.GetUser().Login(ctx, request)
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) VerifyTwoFactor(ctx *gin.Context) {
+	request := &dto.TwoFactorVerifyRequest{}
+
+	err := ctx.ShouldBindJSON(request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -71,16 +512,129 @@ func (c *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	request.UserAgent = ctx.Request.UserAgent()
+	request.IPAddress = ctx.ClientIP()
+
+	user, err := c.service.GetUser().VerifyTwoFactor(ctx.Request.Context(), request)
+	metrics.ObserveLogin(err, false)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
 	response.HttpResponse(response.ParamHTTPResp{
 		Code:  http.StatusOK,
-		Data:  user.User,
+		Data:  user,
 		Token: &user.Token,
 		Gin:   ctx,
 	})
 }
 
-func (c *UserController) Register(ctx *gin.Context) {
-	request := &dto.RegisterRequest{}
+func (c *UserController) DisableTwoFactor(ctx *gin.Context) {
+	err := c.service.GetUser().DisableTwoFactor(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) UnlockUser(ctx *gin.Context) {
+	err := c.service.GetUser().UnlockUser(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) SuspendUser(ctx *gin.Context) {
+	err := c.service.GetUser().SuspendUser(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) ReactivateUser(ctx *gin.Context) {
+	err := c.service.GetUser().ReactivateUser(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) DeleteUser(ctx *gin.Context) {
+	err := c.service.GetUser().DeleteUser(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) CloseAccount(ctx *gin.Context) {
+	request := &dto.CloseAccountRequest{}
 
 	err := ctx.ShouldBindJSON(request)
 	if err != nil {
@@ -93,25 +647,131 @@ func (c *UserController) Register(ctx *gin.Context) {
 		return
 	}
 
-	validate := validator.New()
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = c.service.GetUser().CloseAccount(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) ListUsers(ctx *gin.Context) {
+	request := &dto.UserListRequest{}
+
+	err := ctx.ShouldBindQuery(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	users, meta, err := c.service.GetUser().ListUsers(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: users,
+		Meta: meta,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) UpdateRole(ctx *gin.Context) {
+	request := &dto.UpdateUserRoleRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
 
-	err = validate.Struct(request)
+	err = errCommon.Validate(request)
 	if err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errCommon.WrapError(err)
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
 
+		return
+	}
+
+	user, err := c.service.GetUser().UpdateRole(ctx.Request.Context(), ctx.Param("uuid"), request)
+	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Err:     err,
-			Gin:     ctx,
+			Err: err,
+			Gin: ctx,
 		})
 
 		return
 	}
 
-	user, err := c.service.GetUser().Register(ctx, request)
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: user,
+		Gin:  ctx,
+	})
+}
+
+func (c *UserController) Register(ctx *gin.Context) {
+	request := &dto.RegisterRequest{}
+
+	err := ctx.ShouldBindJSON(request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -122,6 +782,32 @@ func (c *UserController) Register(ctx *gin.Context) {
 		return
 	}
 
+	err = errCommon.Validate(request)
+	if err != nil {
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
+		})
+
+		return
+	}
+
+	user, err := c.service.GetUser().Register(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Err: err,
+			Gin: ctx,
+		})
+
+		return
+	}
+
+	metrics.Registrations.Inc()
+
 	response.HttpResponse(response.ParamHTTPResp{
 		Code: http.StatusOK,
 		Data: user.User,
@@ -144,29 +830,25 @@ func (c *UserController) Update(ctx *gin.Context) {
 		return
 	}
 
-	validate := validator.New()
-	err = validate.Struct(request)
+	err = errCommon.Validate(request)
 	if err != nil {
-		errMessage := http.StatusText(http.StatusUnprocessableEntity)
-		errResponse := errCommon.WrapError(err)
+		errResponse := errCommon.ErrValidationResponse(err, locale.FromContext(ctx.Request.Context()))
 
 		response.HttpResponse(response.ParamHTTPResp{
-			Code:    http.StatusUnprocessableEntity,
-			Message: &errMessage,
-			Data:    errResponse,
-			Err:     err,
-			Gin:     ctx,
+			Code: http.StatusUnprocessableEntity,
+			Data: errResponse,
+			Err:  err,
+			Gin:  ctx,
 		})
 
 		return
 	}
 
-	user, err := c.service.GetUser().Update(ctx, request, uuid)
+	user, err := c.service.GetUser().Update(ctx.Request.Context(), request, uuid)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  ctx,
+			Err: err,
+			Gin: ctx,
 		})
 
 		return
@@ -183,9 +865,8 @@ func (c *UserController) GetUserLogin(ctx *gin.Context) {
 	user, err := c.service.GetUser().GetUserLogin(ctx.Request.Context())
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  ctx,
+			Err: err,
+			Gin: ctx,
 		})
 
 		return
@@ -202,9 +883,8 @@ func (c *UserController) GetUserByUUID(ctx *gin.Context) {
 	user, err := c.service.GetUser().GetUserByUUID(ctx.Request.Context(), ctx.Param("uuid"))
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
-			Code: http.StatusBadRequest,
-			Err:  err,
-			Gin:  ctx,
+			Err: err,
+			Gin: ctx,
 		})
 
 		return
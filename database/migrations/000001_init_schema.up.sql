@@ -0,0 +1,144 @@
+-- Baseline schema. Every statement is guarded so databases that were created
+-- by the old AutoMigrate boot path can adopt versioned migrations: their roles
+-- and users tables already exist, so the columns added since then are added
+-- separately.
+
+CREATE TABLE IF NOT EXISTS roles (
+    id                  BIGSERIAL PRIMARY KEY,
+    code                VARCHAR(15) NOT NULL,
+    name                VARCHAR(20) NOT NULL,
+    two_factor_required BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMPTZ,
+    update_at           TIMESTAMPTZ
+);
+
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS two_factor_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_code ON roles (code);
+
+CREATE TABLE IF NOT EXISTS users (
+    id                        BIGSERIAL PRIMARY KEY,
+    uuid                      UUID NOT NULL,
+    name                      VARCHAR(100) NOT NULL,
+    username                  VARCHAR(30),
+    password                  VARCHAR(255) NOT NULL,
+    phone                     VARCHAR(20) NOT NULL,
+    email                     VARCHAR(100) NOT NULL,
+    role_id                   BIGINT NOT NULL,
+    status                    VARCHAR(20) NOT NULL DEFAULT 'active',
+    email_verified_at         TIMESTAMPTZ,
+    two_factor_secret         VARCHAR(64) NOT NULL DEFAULT '',
+    two_factor_enabled_at     TIMESTAMPTZ,
+    two_factor_last_used_step BIGINT NOT NULL DEFAULT 0,
+    failed_login_attempts     BIGINT NOT NULL DEFAULT 0,
+    last_failed_login_at      TIMESTAMPTZ,
+    locked_until              TIMESTAMPTZ,
+    tokens_revoked_at         TIMESTAMPTZ,
+    created_at                TIMESTAMPTZ,
+    update_at                 TIMESTAMPTZ,
+    deleted_at                TIMESTAMPTZ,
+    CONSTRAINT fk_users_role FOREIGN KEY (role_id) REFERENCES roles (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS username                  VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS status                    VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS email_verified_at         TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS two_factor_secret         VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS two_factor_enabled_at     TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS two_factor_last_used_step BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS failed_login_attempts     BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_failed_login_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS locked_until              TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS tokens_revoked_at         TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_at                TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    id          BIGSERIAL PRIMARY KEY,
+    code        VARCHAR(50) NOT NULL,
+    description VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_permissions_code ON permissions (code);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id       BIGINT NOT NULL,
+    permission_id BIGINT NOT NULL,
+    created_at    TIMESTAMPTZ,
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    family_id  UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    device_id  VARCHAR(100),
+    user_agent VARCHAR(255),
+    ip_address VARCHAR(45),
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    jti        VARCHAR(36) NOT NULL,
+    user_id    BIGINT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_revoked_tokens_jti ON revoked_tokens (jti);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_user_id ON revoked_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_password_reset_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_reset_tokens_token_hash ON password_reset_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_email_verification_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_verification_tokens_token_hash ON email_verification_tokens (token_hash);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_username;
+CREATE UNIQUE INDEX idx_users_username ON users (username);
+
+DROP INDEX IF EXISTS idx_users_phone;
+DROP INDEX IF EXISTS idx_users_email;
+DROP INDEX IF EXISTS idx_users_uuid;
@@ -0,0 +1,11 @@
+-- Uniqueness is enforced only among accounts that have not been deleted, so a
+-- soft-deleted account does not block signing up again with the same email.
+-- Creating these fails if the table already holds duplicates; resolve them
+-- first.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_uuid ON users (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (LOWER(email)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone ON users (phone) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_users_username;
+CREATE UNIQUE INDEX idx_users_username ON users (LOWER(username)) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Preferred language for API messages; NULL falls back to the Accept-Language
+-- header of each request.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(5);
@@ -0,0 +1,7 @@
+-- Users are soft-deleted, so their rows outlive the account. Deleting a role
+-- must not cascade into them; it fails while any user row, deleted or not,
+-- still holds the role.
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_role;
+ALTER TABLE users
+    ADD CONSTRAINT fk_users_role FOREIGN KEY (role_id) REFERENCES roles (id) ON UPDATE CASCADE ON DELETE RESTRICT;
@@ -0,0 +1,62 @@
+package seeders
+
+import (
+	"user-service/constants"
+	"user-service/domain/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// defaultPermissions maps every seeded role to the permissions it starts with.
+// Permissions granted later through the API are left alone.
+var defaultPermissions = map[string][]string{
+	constants.AdminCode: {
+		constants.PermissionUsersRead,
+		constants.PermissionUsersWrite,
+		constants.PermissionRolesRead,
+		constants.PermissionRolesWrite,
+	},
+	"SUPPORT": {
+		constants.PermissionUsersRead,
+	},
+	constants.CustomerCode: {},
+}
+
+func RunPermissionSeeder(db *gorm.DB) {
+	permissions := []models.Permission{
+		{Code: constants.PermissionUsersRead, Description: "Read user accounts"},
+		{Code: constants.PermissionUsersWrite, Description: "Modify user accounts"},
+		{Code: constants.PermissionRolesRead, Description: "Read roles"},
+		{Code: constants.PermissionRolesWrite, Description: "Manage roles"},
+	}
+
+	ids := map[string]uint{}
+	for _, value := range permissions {
+		err := db.FirstOrCreate(&value, models.Permission{Code: value.Code}).Error
+		if err != nil {
+			logrus.Errorf("failed to seed permission: %v", err)
+			panic(err)
+		}
+		ids[value.Code] = value.ID
+		logrus.Infof("permission %s successfully seeded", value.Code)
+	}
+
+	for roleCode, codes := range defaultPermissions {
+		var role models.Role
+		err := db.Where("code = ?", roleCode).First(&role).Error
+		if err != nil {
+			logrus.Errorf("failed to find role %s: %v", roleCode, err)
+			panic(err)
+		}
+
+		for _, code := range codes {
+			rolePermission := models.RolePermission{RoleID: role.ID, PermissionID: ids[code]}
+			err = db.FirstOrCreate(&rolePermission, rolePermission).Error
+			if err != nil {
+				logrus.Errorf("failed to seed role permission: %v", err)
+				panic(err)
+			}
+		}
+	}
+}
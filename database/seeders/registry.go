@@ -16,5 +16,6 @@ func NewSeederRegistry(db *gorm.DB) ISeederRegistry {
 
 func (r *Registry) Run() {
 	RunRoleSeeder(r.db)
+	RunPermissionSeeder(r.db)
 	RunUserSeeder(r.db)
 }
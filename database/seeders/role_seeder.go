@@ -10,8 +10,13 @@ import (
 func RunRoleSeeder(db *gorm.DB) {
 	roles := []models.Role{
 		{
-			Code: "ADMIN",
-			Name: "Administrator",
+			Code:              "ADMIN",
+			Name:              "Administrator",
+			TwoFactorRequired: true,
+		},
+		{
+			Code: "SUPPORT",
+			Name: "Support Agent",
 		},
 		{
 			Code: "CUSTOMER",
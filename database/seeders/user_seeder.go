@@ -1,6 +1,7 @@
 package seeders
 
 import (
+	"time"
 	"user-service/constants"
 	"user-service/domain/models"
 
@@ -12,16 +13,26 @@ import (
 
 func RunUserSeeder(db *gorm.DB) {
 	password, _ := bcrypt.GenerateFromPassword([]byte("1234567890"), bcrypt.DefaultCost)
+	verifiedAt := time.Now()
+
+	var role models.Role
+	err := db.Where("code = ?", constants.AdminCode).First(&role).Error
+	if err != nil {
+		logrus.Errorf("failed to find role %s: %v", constants.AdminCode, err)
+		panic(err)
+	}
+
 	user := models.User{
-		UUID:     uuid.New(),
-		Name:     "Administrator",
-		Email:    "admin@clswork.com",
-		Password: string(password),
-		Phone:    "+6282219193211",
-		RoleID:   constants.Admin,
+		UUID:            uuid.New(),
+		Name:            "Administrator",
+		Email:           "admin@clswork.com",
+		Password:        string(password),
+		Phone:           "+6282219193211",
+		RoleID:          role.ID,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
-	err := db.FirstOrCreate(&user, models.User{Email: user.Email}).Error
+	err = db.FirstOrCreate(&user, models.User{Email: user.Email}).Error
 	if err != nil {
 		logrus.Errorf("failed to seed user: %v", err)
 		panic(err)
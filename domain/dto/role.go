@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type RoleRequest struct {
+	Code              string `json:"code" validate:"required,max=15,uppercase,excludesall= "`
+	Name              string `json:"name" validate:"required,max=20"`
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	// Permissions replaces the role's permission set; leaving it out keeps the
+	// current grants.
+	Permissions *[]string `json:"permissions,omitempty" validate:"omitempty,dive,required"`
+}
+
+type RoleResponse struct {
+	ID                uint       `json:"id"`
+	Code              string     `json:"code"`
+	Name              string     `json:"name"`
+	TwoFactorRequired bool       `json:"twoFactorRequired"`
+	Permissions       []string   `json:"permissions"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty"`
+}
+
+type UpdateUserRoleRequest struct {
+	RoleID uint `json:"roleId" validate:"required"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+	DeviceID     string `json:"deviceId" validate:"omitempty,max=100"`
+	UserAgent    string `json:"-"`
+	IPAddress    string `json:"-"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+type TwoFactorChallenge struct {
+	ChallengeToken string    `json:"challengeToken"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	SetupRequired  bool      `json:"setupRequired"`
+}
+
+type TwoFactorSetupRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauthUri"`
+}
+
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type TwoFactorConfirmResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type TwoFactorEnrollRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode   string `json:"recoveryCode" validate:"required_without=Code"`
+	DeviceID       string `json:"deviceId" validate:"omitempty,max=100"`
+	UserAgent      string `json:"-"`
+	IPAddress      string `json:"-"`
+}
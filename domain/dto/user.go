@@ -1,31 +1,50 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type LoginRequest struct {
-	Username string `json:"username" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Username  string `json:"username" validate:"required"`
+	Password  string `json:"password" validate:"required"`
+	DeviceID  string `json:"deviceId" validate:"omitempty,max=100"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 type UserResponse struct {
-	UUID  uuid.UUID `json:"uuid"`
-	Name  string    `json:"name"`
-	Email string    `json:"email"`
-	Role  string    `json:"role,omitempty"`
-	Phone string    `json:"phone"`
+	UUID            uuid.UUID  `json:"uuid"`
+	Name            string     `json:"name"`
+	Username        *string    `json:"username,omitempty"`
+	Email           string     `json:"email"`
+	Role            string     `json:"role,omitempty"`
+	Phone           string     `json:"phone"`
+	Status          string     `json:"status,omitempty"`
+	Locale          *string    `json:"locale,omitempty"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
 }
 
 type LoginResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User                  UserResponse        `json:"user"`
+	Token                 string              `json:"token,omitempty"`
+	TokenExpiresAt        *time.Time          `json:"tokenExpiresAt,omitempty"`
+	RefreshToken          string              `json:"refreshToken,omitempty"`
+	RefreshTokenExpiresAt *time.Time          `json:"refreshTokenExpiresAt,omitempty"`
+	TwoFactor             *TwoFactorChallenge `json:"twoFactor,omitempty"`
+	RecoveryCodes         []string            `json:"recoveryCodes,omitempty"`
 }
 
 type RegisterRequest struct {
-	Name            string `json:"name" validate:"required"`
-	Email           string `json:"email" validate:"required,email"`
-	Phone           string `json:"phone" validate:"required"`
-	Password        string `json:"password" validate:"required"`
-	ConfirmPassword string `json:"confirmPassword" validate:"required"`
+	Name            string  `json:"name" validate:"required"`
+	Username        *string `json:"username,omitempty" validate:"omitempty,min=3,max=30,excludesall=@+ ,username"`
+	Email           string  `json:"email" validate:"required,email"`
+	Phone           string  `json:"phone" validate:"required"`
+	Password        string  `json:"password" validate:"required"`
+	ConfirmPassword string  `json:"confirmPassword" validate:"required"`
+	Locale          *string `json:"locale,omitempty" validate:"omitempty,oneof=en id"`
 	RoleID          uint
 }
 
@@ -35,13 +54,49 @@ type RegisterRespose struct {
 
 type UpdateRequest struct {
 	Name            string  `json:"name" validate:"required"`
+	Username        *string `json:"username,omitempty" validate:"omitempty,min=3,max=30,excludesall=@+ ,username"`
 	Email           string  `json:"email" validate:"required,email"`
 	Phone           string  `json:"phone" validate:"required"`
 	Password        *string `json:"password,omitempty"`
 	ConfirmPassword *string `json:"confirmPassword,omitempty"`
-	RoleID          uint
+	Locale          *string `json:"locale,omitempty" validate:"omitempty,oneof=en id"`
 }
 
 type UpdateResponse struct {
 	User UserResponse `json:"user"`
 }
+
+type UserListRequest struct {
+	Page        int         `form:"page" validate:"omitempty,min=1"`
+	Limit       int         `form:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor      string      `form:"cursor" validate:"excluded_with=Page"`
+	Role        string      `form:"role" validate:"omitempty,max=15"`
+	CreatedFrom *time.Time  `form:"createdFrom"`
+	CreatedTo   *time.Time  `form:"createdTo"`
+	Verified    *bool       `form:"verified"`
+	Status      string      `form:"status" validate:"omitempty,oneof=active suspended deactivated"`
+	Search      string      `form:"search" validate:"omitempty,max=100"`
+	Sort        string      `form:"sort" validate:"omitempty,oneof=created_at name email"`
+	Order       string      `form:"order" validate:"omitempty,oneof=asc desc"`
+	After       *UserCursor `form:"-"`
+}
+
+// UserCursor is the decoded form of UserListRequest.Cursor: the sort value and
+// ID of the last user on the previous page.
+type UserCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// AccountState is the part of the stored account consulted on every
+// authenticated request.
+type AccountState struct {
+	Status string
+	Locale *string
+}
+
+type CloseAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type Permission struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	Code        string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Description string `gorm:"type:varchar(100);not null"`
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
+
+type RolePermission struct {
+	RoleID       uint `gorm:"primaryKey"`
+	PermissionID uint `gorm:"primaryKey"`
+	CreatedAt    *time.Time
+	Role         Role       `gorm:"foreignKey:role_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Permission   Permission `gorm:"foreignKey:permission_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
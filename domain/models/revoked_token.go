@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type RevokedToken struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	JTI       string    `gorm:"type:varchar(36);not null;uniqueIndex"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt *time.Time
+}
@@ -4,17 +4,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type User struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID `gorm:"type:uuid;not null"`
-	Name      string    `gorm:"varchar(100);not null"`
-	Password  string    `gorm:"varcher(255);not null"`
-	Phone     string    `gorm:"varchar(15);not null"`
-	Email     string    `gorm:"varcher(100);not null"`
-	RoleID    uint      `gorm:"type:uint;not null"`
-	CreatedAt *time.Time
-	UpdateAt  *time.Time
-	Role      Role `gorm:"foreignKey:role_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID                    uint      `gorm:"primaryKey;autoIncrement"`
+	UUID                  uuid.UUID `gorm:"type:uuid;not null"`
+	Name                  string    `gorm:"varchar(100);not null"`
+	Username              *string   `gorm:"type:varchar(30);uniqueIndex"`
+	Password              string    `gorm:"varcher(255);not null"`
+	Phone                 string    `gorm:"varchar(15);not null"`
+	Email                 string    `gorm:"varcher(100);not null"`
+	RoleID                uint      `gorm:"type:uint;not null"`
+	Status                string    `gorm:"type:varchar(20);not null;default:'active'"`
+	Locale                *string   `gorm:"type:varchar(5)"`
+	EmailVerifiedAt       *time.Time
+	TwoFactorSecret       string `gorm:"type:varchar(64);not null;default:''"`
+	TwoFactorEnabledAt    *time.Time
+	TwoFactorLastUsedStep int64 `gorm:"not null;default:0"`
+	FailedLoginAttempts   int   `gorm:"not null;default:0"`
+	LastFailedLoginAt     *time.Time
+	LockedUntil           *time.Time
+	TokensRevokedAt       *time.Time
+	CreatedAt             *time.Time
+	UpdateAt              *time.Time
+	DeletedAt             gorm.DeletedAt `gorm:"index"`
+	Role                  Role           `gorm:"foreignKey:role_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 }
@@ -2,37 +2,47 @@ package middlewares
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
-	"fmt"
+	"errors"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"user-service/common/keyset"
+	"user-service/common/locale"
+	"user-service/common/logger"
+	"user-service/common/metrics"
 	"user-service/common/response"
-	"user-service/config"
 	"user-service/constants"
-	services "user-service/services/user"
+	"user-service/domain/dto"
+	"user-service/services"
+	userServices "user-service/services/user"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	errConstants "user-service/constants/error"
 )
 
+var registry services.IServiceRegistry
+
+// Init hands the middlewares the services they consult on every request, such
+// as the token revocation store. It must be called before the router starts.
+func Init(service services.IServiceRegistry) {
+	registry = service
+}
+
 func HandlePanic() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
-				logrus.Errorf("recovered from panic: %v", r)
+				logger.FromContext(ctx.Request.Context()).Errorf("recovered from panic: %v", r)
 
-				ctx.JSON(http.StatusInternalServerError, response.Response{
-					Status:  constants.Error,
-					Message: errConstants.ErrInternalServerError.Error(),
-				})
-
-				ctx.Abort()
+				responseError(ctx, errConstants.ErrInternalServerError)
 			}
 		}()
 
@@ -40,15 +50,91 @@ func HandlePanic() gin.HandlerFunc {
 	}
 }
 
+// RequestID tags the request with the incoming X-Request-Id, or a new one,
+// echoes it in the response and attaches a logger carrying it to the request
+// context. Once the request completes it writes the access log line.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		requestID := ctx.GetHeader(constants.XRequestID)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		ctx.Header(constants.XRequestID, requestID)
+
+		entry := logger.FromContext(ctx.Request.Context()).WithFields(logrus.Fields{
+			"request_id": requestID,
+			"method":     ctx.Request.Method,
+			"route":      ctx.FullPath(),
+		})
+		ctx.Request = ctx.Request.WithContext(logger.WithContext(ctx.Request.Context(), entry))
+
+		ctx.Next()
+
+		logger.FromContext(ctx.Request.Context()).WithFields(logrus.Fields{
+			"path":       ctx.Request.URL.Path,
+			"status":     ctx.Writer.Status(),
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip":  ctx.ClientIP(),
+		}).Info("request completed")
+	}
+}
+
+// validRequestID accepts caller supplied IDs only when they are short and
+// printable, so they cannot be used to forge log lines.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > 128 {
+		return false
+	}
+
+	for _, r := range requestID {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Locale negotiates the language of the response messages from the
+// Accept-Language header. Authenticate replaces it with the caller's stored
+// preference, when they have one.
+func Locale() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		lang := locale.Parse(ctx.GetHeader(constants.AcceptLanguage))
+		ctx.Request = ctx.Request.WithContext(locale.WithContext(ctx.Request.Context(), lang))
+		ctx.Header("Content-Language", lang)
+
+		ctx.Next()
+	}
+}
+
+// Metrics records the count and latency of every request, labelled by the
+// route template rather than the raw path to keep label cardinality bounded.
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(ctx.Writer.Status())
+
+		metrics.HTTPRequests.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
 func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		err := tollbooth.LimitByRequest(lmt, ctx.Writer, ctx.Request)
 		if err != nil {
-			ctx.JSON(http.StatusTooManyRequests, response.Response{
-				Status:  constants.Error,
-				Message: errConstants.ErrToManyRequest.Error(),
-			})
-			ctx.Abort()
+			metrics.RateLimited.Inc()
+			responseError(ctx, errConstants.ErrToManyRequest)
 		}
 		ctx.Next()
 	}
@@ -64,63 +150,81 @@ func extractBearerToken(token string) string {
 	}
 }
 
-func responseUnauthorize(ctx *gin.Context, message string) {
-	ctx.JSON(http.StatusUnauthorized, response.Response{
-		Status:  constants.Error,
-		Message: message,
+// responseError answers with the status and code of err and stops the chain.
+// Errors without a status of their own are reported as unauthorized.
+func responseError(ctx *gin.Context, err error) {
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusUnauthorized,
+		Err:  err,
+		Gin:  ctx,
 	})
 
 	ctx.Abort()
 }
 
-func validateApiKey(ctx *gin.Context) error {
-	apiKey := ctx.GetHeader(constants.XApiKey)
-	requestAt := ctx.GetHeader(constants.XRequestAt)
-	serviceName := ctx.GetHeader(constants.XServiceName)
-	signatureKey := config.Config.SignatureKey
-
-	validateKey := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
-
-	hash := sha256.New()
-	hash.Write([]byte(validateKey))
-	resultHash := hex.EncodeToString(hash.Sum(nil))
-
-	logrus.Printf("api-key: %s", resultHash)
-
-	if apiKey != resultHash {
-		return errConstants.ErrUnauthorize
+func hasPermission(claims *userServices.Claims, permissions []string) bool {
+	for _, permission := range permissions {
+		if slices.Contains(claims.Permissions, permission) {
+			return true
+		}
 	}
 
-	return nil
+	return false
 }
 
 func validateBearerToken(ctx *gin.Context, token string) error {
 	if !strings.Contains(token, "Bearer") {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonMalformed).Inc()
 		return errConstants.ErrUnauthorize
 	}
 
 	tokenString := extractBearerToken(token)
 	if tokenString == "" {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonMalformed).Inc()
 		return errConstants.ErrUnauthorize
 	}
 
-	claims := &services.Claims{}
-	tokenJwt, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-		_, ok := t.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, errConstants.ErrInvalidToken
-		}
-		jwtSecret := []byte(config.Config.JwtSecretKey)
+	claims := &userServices.Claims{}
+	tokenJwt, err := jwt.ParseWithClaims(tokenString, claims, keyset.Keyfunc, jwt.WithValidMethods(keyset.ValidMethods()))
 
-		return jwtSecret, nil
-	})
+	if err != nil || !tokenJwt.Valid || claims.ID == "" || claims.IssuedAt == nil || claims.User == nil {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonInvalid).Inc()
+		return errConstants.ErrUnauthorize
+	}
+
+	// Checked before revocation: suspending an account also revokes its
+	// tokens, and the caller should learn why they were turned away.
+	account, err := registry.GetUser().CheckStatus(ctx.Request.Context(), claims.User.UUID.String())
+	if errors.Is(err, errConstants.ErrAccountSuspended) || errors.Is(err, errConstants.ErrAccountClosed) {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonInactive).Inc()
+		return err
+	}
+	if err != nil {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonInvalid).Inc()
+		return errConstants.ErrUnauthorize
+	}
 
-	if err != nil || !tokenJwt.Valid {
+	revoked, err := registry.GetRevocation().IsRevoked(ctx.Request.Context(), claims.ID, claims.User.UUID.String(), claims.IssuedAt.Time)
+	if err != nil {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonInvalid).Inc()
 		return errConstants.ErrUnauthorize
 	}
 
-	userLogin := ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), constants.UserLogin, claims.User))
-	ctx.Request = userLogin
+	if revoked {
+		metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonRevoked).Inc()
+		return errConstants.ErrTokenRevoked
+	}
+
+	requestCtx := context.WithValue(ctx.Request.Context(), constants.UserLogin, claims.User)
+	requestCtx = context.WithValue(requestCtx, constants.TokenClaims, claims)
+	requestCtx = logger.With(requestCtx, "user_uuid", claims.User.UUID.String())
+	// The stored preference, not the one in the claims, so a change applies
+	// without waiting for a new token.
+	if account.Locale != nil && locale.Supported(*account.Locale) {
+		requestCtx = locale.WithContext(requestCtx, *account.Locale)
+		ctx.Header("Content-Language", *account.Locale)
+	}
+	ctx.Request = ctx.Request.WithContext(requestCtx)
 	ctx.Set(constants.Token, token)
 
 	return nil
@@ -132,19 +236,63 @@ func Authenticate() gin.HandlerFunc {
 		token := ctx.GetHeader(constants.Authorization)
 
 		if token == "" {
-			responseUnauthorize(ctx, errConstants.ErrUnauthorize.Error())
+			metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonMissing).Inc()
+			responseError(ctx, errConstants.ErrUnauthorize)
 			return
 		}
 
 		err = validateBearerToken(ctx, token)
 		if err != nil {
-			responseUnauthorize(ctx, err.Error())
+			responseError(ctx, err)
 			return
 		}
 
 		err = validateApiKey(ctx)
 		if err != nil {
-			responseUnauthorize(ctx, err.Error())
+			metrics.TokenValidationFailures.WithLabelValues(metrics.ReasonAPIKey).Inc()
+			responseError(ctx, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequirePermission lets the request through only when the token claims grant
+// every one of permissions. It must run after Authenticate.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ok := ctx.Request.Context().Value(constants.TokenClaims).(*userServices.Claims)
+		if !ok {
+			responseError(ctx, errConstants.ErrForbidden)
+			return
+		}
+
+		for _, permission := range permissions {
+			if !slices.Contains(claims.Permissions, permission) {
+				responseError(ctx, errConstants.ErrForbidden)
+				return
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// AuthorizeSelf guards per-user routes: the request passes when the path
+// parameter param holds the caller's own UUID, or when the caller has one of
+// permissions. It must run after Authenticate.
+func AuthorizeSelf(param string, permissions ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user, ok := ctx.Request.Context().Value(constants.UserLogin).(*dto.UserResponse)
+		claims, _ := ctx.Request.Context().Value(constants.TokenClaims).(*userServices.Claims)
+		if !ok || claims == nil {
+			responseError(ctx, errConstants.ErrForbidden)
+			return
+		}
+
+		if user.UUID.String() != ctx.Param(param) && !hasPermission(claims, permissions) {
+			responseError(ctx, errConstants.ErrForbidden)
 			return
 		}
 
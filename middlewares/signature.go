@@ -0,0 +1,122 @@
+package middlewares
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"user-service/common/logger"
+	"user-service/common/signature"
+	"user-service/config"
+	"user-service/constants"
+
+	"github.com/gin-gonic/gin"
+	"github.com/patrickmn/go-cache"
+
+	errConstants "user-service/constants/error"
+)
+
+const (
+	defaultSignatureMaxSkew     = 5 * time.Minute
+	defaultSignatureMaxBodySize = 1 << 20
+	maxNonceLength              = 128
+)
+
+// seenNonces remembers the nonces of accepted signatures until their
+// timestamp leaves the skew window, after which the timestamp check alone
+// rejects a replay. The cache is per replica.
+var seenNonces = cache.New(2*defaultSignatureMaxSkew, 10*time.Minute)
+
+// validateApiKey checks the HMAC-SHA256 signature calling services put in
+// X-Signature over the method, path, body, X-Request-At (Unix seconds) and
+// X-Nonce of the request. The timestamp must be within the configured skew
+// of our clock and a nonce is accepted once.
+func validateApiKey(ctx *gin.Context) error {
+	signatureKey := config.Config.SignatureKey
+	if signatureKey == "" {
+		logger.FromContext(ctx.Request.Context()).Error("request signature rejected: signature key is not configured")
+		return errConstants.ErrUnauthorize
+	}
+
+	request := signature.Request{
+		ServiceName: ctx.GetHeader(constants.XServiceName),
+		Method:      ctx.Request.Method,
+		Path:        ctx.Request.URL.RequestURI(),
+		Timestamp:   ctx.GetHeader(constants.XRequestAt),
+		Nonce:       ctx.GetHeader(constants.XNonce),
+	}
+
+	if request.ServiceName == "" || request.Nonce == "" || len(request.Nonce) > maxNonceLength {
+		return rejectSignature(ctx, "missing service name or nonce")
+	}
+
+	requestAt, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return rejectSignature(ctx, "malformed timestamp")
+	}
+
+	skew := time.Since(time.Unix(requestAt, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > signatureMaxSkew() {
+		return rejectSignature(ctx, "timestamp outside the allowed skew")
+	}
+
+	// The body is hashed before the signature can be checked, so how much
+	// of it is buffered must be bounded.
+	if ctx.Request.Body != nil {
+		request.Body, err = io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, signatureMaxBodySize()))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			rejectSignature(ctx, "body too large")
+			return errConstants.ErrRequestTooLarge
+		}
+		if err != nil {
+			return rejectSignature(ctx, "unreadable body")
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(request.Body))
+	}
+
+	if !signature.Verify(signatureKey, request, ctx.GetHeader(constants.XSignature)) {
+		return rejectSignature(ctx, "signature mismatch")
+	}
+
+	// Recorded only once the signature holds, so forged requests cannot
+	// burn nonces of legitimate callers.
+	err = seenNonces.Add(request.ServiceName+"\n"+request.Nonce, struct{}{}, 2*signatureMaxSkew())
+	if err != nil {
+		return rejectSignature(ctx, "nonce already used")
+	}
+
+	return nil
+}
+
+// rejectSignature logs why a signature was turned away along with the claimed
+// service name, never the signature, nonce or key, and returns the error the
+// caller sees.
+func rejectSignature(ctx *gin.Context, reason string) error {
+	logger.FromContext(ctx.Request.Context()).
+		WithField("service_name", ctx.GetHeader(constants.XServiceName)).
+		Warnf("request signature rejected: %s", reason)
+
+	return errConstants.ErrUnauthorize
+}
+
+func signatureMaxBodySize() int64 {
+	if config.Config.SignatureMaxBodyBytes > 0 {
+		return config.Config.SignatureMaxBodyBytes
+	}
+
+	return defaultSignatureMaxBodySize
+}
+
+func signatureMaxSkew() time.Duration {
+	if config.Config.SignatureMaxSkewSecond > 0 {
+		return time.Duration(config.Config.SignatureMaxSkewSecond) * time.Second
+	}
+
+	return defaultSignatureMaxSkew
+}
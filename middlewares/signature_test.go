@@ -0,0 +1,175 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"user-service/common/signature"
+	"user-service/config"
+	"user-service/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testSignatureKey = "secret"
+
+type signedRequest struct {
+	method    string
+	path      string
+	body      string
+	timestamp string
+	nonce     string
+	service   string
+	// signedBody, when set, is what the signature covers instead of body.
+	signedBody *string
+}
+
+func newSignatureRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	config.Config.SignatureKey = testSignatureKey
+	config.Config.SignatureMaxSkewSecond = 60
+	config.Config.SignatureMaxBodyBytes = 64
+
+	router := gin.New()
+	router.Any("/*path", func(ctx *gin.Context) {
+		err := validateApiKey(ctx)
+		if err != nil {
+			responseError(ctx, err)
+			return
+		}
+
+		// The handler must still see the body after it was hashed.
+		body, _ := ctx.GetRawData()
+		ctx.String(http.StatusOK, string(body))
+	})
+
+	return router
+}
+
+func (r signedRequest) send(router *gin.Engine) *httptest.ResponseRecorder {
+	if r.method == "" {
+		r.method = http.MethodPost
+	}
+	if r.path == "" {
+		r.path = "/api/v1/users?page=1"
+	}
+	if r.service == "" {
+		r.service = "order-service"
+	}
+
+	signedBody := r.body
+	if r.signedBody != nil {
+		signedBody = *r.signedBody
+	}
+
+	sig := signature.Sign(testSignatureKey, signature.Request{
+		ServiceName: r.service,
+		Method:      r.method,
+		Path:        r.path,
+		Body:        []byte(signedBody),
+		Timestamp:   r.timestamp,
+		Nonce:       r.nonce,
+	})
+
+	request := httptest.NewRequest(r.method, r.path, strings.NewReader(r.body))
+	request.Header.Set(constants.XServiceName, r.service)
+	request.Header.Set(constants.XRequestAt, r.timestamp)
+	request.Header.Set(constants.XNonce, r.nonce)
+	request.Header.Set(constants.XSignature, sig)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func unix(offset time.Duration) string {
+	return strconv.FormatInt(time.Now().Add(offset).Unix(), 10)
+}
+
+func TestValidateApiKey(t *testing.T) {
+	router := newSignatureRouter()
+	otherBody := `{"name":"b"}`
+
+	tests := []struct {
+		name    string
+		request signedRequest
+		want    int
+	}{
+		{name: "valid", request: signedRequest{body: `{"name":"a"}`, timestamp: unix(0), nonce: "valid"}, want: http.StatusOK},
+		{name: "within skew in the past", request: signedRequest{timestamp: unix(-50 * time.Second), nonce: "past-ok"}, want: http.StatusOK},
+		{name: "within skew in the future", request: signedRequest{timestamp: unix(50 * time.Second), nonce: "future-ok"}, want: http.StatusOK},
+		{name: "beyond skew in the past", request: signedRequest{timestamp: unix(-2 * time.Minute), nonce: "past"}, want: http.StatusUnauthorized},
+		{name: "beyond skew in the future", request: signedRequest{timestamp: unix(2 * time.Minute), nonce: "future"}, want: http.StatusUnauthorized},
+		{name: "malformed timestamp", request: signedRequest{timestamp: "yesterday", nonce: "malformed"}, want: http.StatusUnauthorized},
+		{name: "missing nonce", request: signedRequest{timestamp: unix(0)}, want: http.StatusUnauthorized},
+		{name: "oversized nonce", request: signedRequest{timestamp: unix(0), nonce: strings.Repeat("n", maxNonceLength+1)}, want: http.StatusUnauthorized},
+		{name: "tampered body", request: signedRequest{body: `{"name":"a"}`, signedBody: &otherBody, timestamp: unix(0), nonce: "tampered"}, want: http.StatusUnauthorized},
+		{name: "body over the limit", request: signedRequest{body: strings.Repeat("a", 65), timestamp: unix(0), nonce: "large"}, want: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tt.request.send(router)
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", recorder.Code, tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestValidateApiKeyRestoresBody(t *testing.T) {
+	router := newSignatureRouter()
+
+	recorder := signedRequest{body: `{"name":"a"}`, timestamp: unix(0), nonce: "restore"}.send(router)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != `{"name":"a"}` {
+		t.Fatalf("got %d %q, want the original body", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestValidateApiKeyRejectsReplay(t *testing.T) {
+	router := newSignatureRouter()
+	request := signedRequest{timestamp: unix(0), nonce: "replay"}
+
+	if code := request.send(router).Code; code != http.StatusOK {
+		t.Fatalf("first request status = %d, want %d", code, http.StatusOK)
+	}
+
+	if code := request.send(router).Code; code != http.StatusUnauthorized {
+		t.Fatalf("replayed request status = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	// Nonces are scoped to the calling service.
+	request.service = "billing-service"
+	if code := request.send(router).Code; code != http.StatusOK {
+		t.Fatalf("other service status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestValidateApiKeyForgedRequestDoesNotBurnNonce(t *testing.T) {
+	router := newSignatureRouter()
+	otherBody := "forged"
+
+	forged := signedRequest{body: "x", signedBody: &otherBody, timestamp: unix(0), nonce: "burn"}
+	if code := forged.send(router).Code; code != http.StatusUnauthorized {
+		t.Fatalf("forged request status = %d, want %d", code, http.StatusUnauthorized)
+	}
+
+	genuine := signedRequest{body: "x", timestamp: unix(0), nonce: "burn"}
+	if code := genuine.send(router).Code; code != http.StatusOK {
+		t.Fatalf("genuine request status = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestValidateApiKeyWithoutKey(t *testing.T) {
+	router := newSignatureRouter()
+	config.Config.SignatureKey = ""
+	defer func() { config.Config.SignatureKey = testSignatureKey }()
+
+	if code := (signedRequest{timestamp: unix(0), nonce: "no-key"}).send(router).Code; code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
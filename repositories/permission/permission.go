@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+
+	commonErr "user-service/common/error"
+)
+
+type PermissionRepository struct {
+	db *gorm.DB
+}
+
+type IPermissionRepository interface {
+	FindAll(context.Context) ([]models.Permission, error)
+	FindByCodes(context.Context, []string) ([]models.Permission, error)
+	FindCodesByRoleID(context.Context, uint) ([]string, error)
+}
+
+func NewPermissionRepository(db *gorm.DB) IPermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+func (r *PermissionRepository) FindAll(ctx context.Context) ([]models.Permission, error) {
+	var permissions []models.Permission
+
+	err := r.db.WithContext(ctx).Order("code").Find(&permissions).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return permissions, nil
+}
+
+func (r *PermissionRepository) FindByCodes(ctx context.Context, codes []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+
+	err := r.db.WithContext(ctx).Where("code IN ?", codes).Find(&permissions).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return permissions, nil
+}
+
+func (r *PermissionRepository) FindCodesByRoleID(ctx context.Context, roleID uint) ([]string, error) {
+	var codes []string
+
+	err := r.db.WithContext(ctx).
+		Model(&models.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id = ?", roleID).
+		Order("permissions.code").
+		Pluck("permissions.code", &codes).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return codes, nil
+}
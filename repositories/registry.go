@@ -1,8 +1,13 @@
 package repositories
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
+	permissionRepositories "user-service/repositories/permission"
+	roleRepositories "user-service/repositories/role"
+	tokenRepositories "user-service/repositories/token"
 	repositories "user-service/repositories/user"
 )
 
@@ -12,6 +17,14 @@ type Registry struct {
 
 type IRepositoryRegistry interface {
 	GetUser() repositories.IUserRepository
+	GetRole() roleRepositories.IRoleRepository
+	GetPermission() permissionRepositories.IPermissionRepository
+	GetRefreshToken() tokenRepositories.IRefreshTokenRepository
+	GetRevokedToken() tokenRepositories.IRevokedTokenRepository
+	GetPasswordResetToken() tokenRepositories.IPasswordResetTokenRepository
+	GetEmailVerificationToken() tokenRepositories.IEmailVerificationTokenRepository
+	GetRecoveryCode() tokenRepositories.IRecoveryCodeRepository
+	Transaction(context.Context, func(IRepositoryRegistry) error) error
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
@@ -21,3 +34,39 @@ func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
 func (r *Registry) GetUser() repositories.IUserRepository {
 	return repositories.NewUserRepository(r.db)
 }
+
+func (r *Registry) GetRefreshToken() tokenRepositories.IRefreshTokenRepository {
+	return tokenRepositories.NewRefreshTokenRepository(r.db)
+}
+
+func (r *Registry) GetRevokedToken() tokenRepositories.IRevokedTokenRepository {
+	return tokenRepositories.NewRevokedTokenRepository(r.db)
+}
+
+func (r *Registry) GetPasswordResetToken() tokenRepositories.IPasswordResetTokenRepository {
+	return tokenRepositories.NewPasswordResetTokenRepository(r.db)
+}
+
+func (r *Registry) GetEmailVerificationToken() tokenRepositories.IEmailVerificationTokenRepository {
+	return tokenRepositories.NewEmailVerificationTokenRepository(r.db)
+}
+
+func (r *Registry) GetRecoveryCode() tokenRepositories.IRecoveryCodeRepository {
+	return tokenRepositories.NewRecoveryCodeRepository(r.db)
+}
+
+func (r *Registry) GetRole() roleRepositories.IRoleRepository {
+	return roleRepositories.NewRoleRepository(r.db)
+}
+
+func (r *Registry) GetPermission() permissionRepositories.IPermissionRepository {
+	return permissionRepositories.NewPermissionRepository(r.db)
+}
+
+// Transaction runs fn with a registry whose repositories share one database
+// transaction. It commits when fn returns nil and rolls back otherwise.
+func (r *Registry) Transaction(ctx context.Context, fn func(IRepositoryRegistry) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Registry{db: tx})
+	})
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+
+	commonErr "user-service/common/error"
+	constantErr "user-service/constants/error"
+)
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+type IRoleRepository interface {
+	Create(context.Context, *models.Role, []uint) (*models.Role, error)
+	Update(context.Context, *models.Role, []uint) (*models.Role, error)
+	Delete(context.Context, uint) error
+	FindAll(context.Context) ([]models.Role, error)
+	FindByID(context.Context, uint) (*models.Role, error)
+	FindByCode(context.Context, string) (*models.Role, error)
+	CountUsers(context.Context, uint) (int64, error)
+}
+
+func NewRoleRepository(db *gorm.DB) IRoleRepository {
+	return &RoleRepository{db: db}
+}
+
+// Create stores role together with its permission grants.
+func (r *RoleRepository) Create(ctx context.Context, role *models.Role, permissionIDs []uint) (*models.Role, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(role).Error
+		if err != nil {
+			return err
+		}
+
+		return replacePermissions(tx, role.ID, permissionIDs)
+	})
+	if err != nil {
+		return nil, translateError(ctx, err)
+	}
+
+	return role, nil
+}
+
+// Update saves the role columns and, when permissionIDs is non-nil, replaces
+// its permission grants in the same transaction.
+func (r *RoleRepository) Update(ctx context.Context, role *models.Role, permissionIDs []uint) (*models.Role, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Role{}).Where("id = ?", role.ID).Updates(map[string]any{
+			"code":                role.Code,
+			"name":                role.Name,
+			"two_factor_required": role.TwoFactorRequired,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if permissionIDs == nil {
+			return nil
+		}
+
+		return replacePermissions(tx, role.ID, permissionIDs)
+	})
+	if err != nil {
+		return nil, translateError(ctx, err)
+	}
+
+	return role, nil
+}
+
+func (r *RoleRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Role{}).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *RoleRepository) FindAll(ctx context.Context) ([]models.Role, error) {
+	var roles []models.Role
+
+	err := r.db.WithContext(ctx).Order("id").Find(&roles).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) FindByID(ctx context.Context, id uint) (*models.Role, error) {
+	var role models.Role
+
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constantErr.ErrRoleNotFound
+		}
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return &role, nil
+}
+
+func (r *RoleRepository) FindByCode(ctx context.Context, code string) (*models.Role, error) {
+	var role models.Role
+
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constantErr.ErrRoleNotFound
+		}
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return &role, nil
+}
+
+// CountUsers counts the users holding the role, soft-deleted ones included:
+// their rows still reference it.
+func (r *RoleRepository) CountUsers(ctx context.Context, id uint) (int64, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("role_id = ?", id).Count(&count).Error
+	if err != nil {
+		return 0, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return count, nil
+}
+
+func replacePermissions(tx *gorm.DB, roleID uint, permissionIDs []uint) error {
+	err := tx.Where("role_id = ?", roleID).Delete(&models.RolePermission{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(permissionIDs) == 0 {
+		return nil
+	}
+
+	rolePermissions := make([]models.RolePermission, 0, len(permissionIDs))
+	for _, permissionID := range permissionIDs {
+		rolePermissions = append(rolePermissions, models.RolePermission{RoleID: roleID, PermissionID: permissionID})
+	}
+
+	return tx.Create(&rolePermissions).Error
+}
+
+func translateError(ctx context.Context, err error) error {
+	if commonErr.UniqueConstraint(err) == "idx_roles_code" {
+		return constantErr.ErrRoleExists
+	}
+
+	return commonErr.WrapSQLError(ctx, err)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+
+	commonErr "user-service/common/error"
+	constantErr "user-service/constants/error"
+)
+
+type EmailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+type IEmailVerificationTokenRepository interface {
+	Create(context.Context, *models.EmailVerificationToken) (*models.EmailVerificationToken, error)
+	FindByTokenHash(context.Context, string) (*models.EmailVerificationToken, error)
+	MarkUsed(context.Context, uint) error
+	InvalidateByUserID(context.Context, uint) error
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) IEmailVerificationTokenRepository {
+	return &EmailVerificationTokenRepository{db: db}
+}
+
+func (r *EmailVerificationTokenRepository) Create(ctx context.Context, token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) {
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return token, nil
+}
+
+func (r *EmailVerificationTokenRepository) FindByTokenHash(ctx context.Context, hash string) (*models.EmailVerificationToken, error) {
+	var token models.EmailVerificationToken
+
+	err := r.db.WithContext(ctx).Preload("User").Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constantErr.ErrInvalidVerificationToken
+		}
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed fails with ErrInvalidVerificationToken when the token was already used.
+func (r *EmailVerificationTokenRepository) MarkUsed(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return commonErr.WrapSQLError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return constantErr.ErrInvalidVerificationToken
+	}
+
+	return nil
+}
+
+func (r *EmailVerificationTokenRepository) InvalidateByUserID(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
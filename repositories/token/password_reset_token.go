@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+
+	commonErr "user-service/common/error"
+	constantErr "user-service/constants/error"
+)
+
+type PasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+type IPasswordResetTokenRepository interface {
+	Create(context.Context, *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	FindByTokenHash(context.Context, string) (*models.PasswordResetToken, error)
+	MarkUsed(context.Context, uint) error
+	InvalidateByUserID(context.Context, uint) error
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) IPasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{db: db}
+}
+
+func (r *PasswordResetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return token, nil
+}
+
+func (r *PasswordResetTokenRepository) FindByTokenHash(ctx context.Context, hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+
+	err := r.db.WithContext(ctx).Preload("User").Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constantErr.ErrInvalidResetToken
+		}
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed consumes the token. Only one of several concurrent resets with the
+// same token succeeds; the others get ErrInvalidResetToken.
+func (r *PasswordResetTokenRepository) MarkUsed(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return commonErr.WrapSQLError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return constantErr.ErrInvalidResetToken
+	}
+
+	return nil
+}
+
+func (r *PasswordResetTokenRepository) InvalidateByUserID(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
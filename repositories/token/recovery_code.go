@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+
+	commonErr "user-service/common/error"
+	constantErr "user-service/constants/error"
+)
+
+type RecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+type IRecoveryCodeRepository interface {
+	Replace(context.Context, uint, []string) error
+	Consume(context.Context, uint, string) error
+	DeleteByUserID(context.Context, uint) error
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) IRecoveryCodeRepository {
+	return &RecoveryCodeRepository{db: db}
+}
+
+// Replace discards every recovery code of the user and stores the given hashes.
+func (r *RecoveryCodeRepository) Replace(ctx context.Context, userID uint, hashes []string) error {
+	codes := make([]models.RecoveryCode, 0, len(hashes))
+	for _, hash := range hashes {
+		codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeRepository) Consume(ctx context.Context, userID uint, hash string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return commonErr.WrapSQLError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return constantErr.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	commonErr "user-service/common/error"
+	constantErr "user-service/constants/error"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+type IRefreshTokenRepository interface {
+	Create(context.Context, *models.RefreshToken) (*models.RefreshToken, error)
+	FindByTokenHash(context.Context, string) (*models.RefreshToken, error)
+	MarkRotated(context.Context, uint) error
+	RevokeFamily(context.Context, uuid.UUID) error
+	RevokeByUserID(context.Context, uint) error
+	RevokeByRoleID(context.Context, uint) error
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) IRefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error) {
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) FindByTokenHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+
+	err := r.db.WithContext(ctx).Preload("User.Role").Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constantErr.ErrInvalidRefreshToken
+		}
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return &token, nil
+}
+
+// MarkRotated flags the token as used. The update is conditional so that two
+// concurrent refreshes with the same token cannot both succeed; the loser gets
+// ErrRefreshTokenReused.
+func (r *RefreshTokenRepository) MarkRotated(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", time.Now())
+	if result.Error != nil {
+		return commonErr.WrapSQLError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return constantErr.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUserID(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+// RevokeByRoleID revokes the refresh tokens of every user holding the role.
+func (r *RefreshTokenRepository) RevokeByRoleID(ctx context.Context, roleID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id IN (?) AND revoked_at IS NULL", r.db.Model(&models.User{}).Select("id").Where("role_id = ?", roleID)).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"user-service/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	commonErr "user-service/common/error"
+)
+
+type RevokedTokenRepository struct {
+	db *gorm.DB
+}
+
+type IRevokedTokenRepository interface {
+	Create(context.Context, *models.RevokedToken) error
+	ExistsByJTI(context.Context, string) (bool, error)
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) IRevokedTokenRepository {
+	return &RevokedTokenRepository{db: db}
+}
+
+func (r *RevokedTokenRepository) Create(ctx context.Context, token *models.RevokedToken) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *RevokedTokenRepository) ExistsByJTI(ctx context.Context, jti string) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	if err != nil {
+		return false, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return count > 0, nil
+}
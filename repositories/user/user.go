@@ -3,11 +3,16 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"user-service/constants"
 	"user-service/domain/dto"
 	"user-service/domain/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	commonErr "user-service/common/error"
 	constantErr "user-service/constants/error"
@@ -21,8 +26,23 @@ type IUserRepository interface {
 	Register(context.Context, *dto.RegisterRequest) (*models.User, error)
 	Update(context.Context, *dto.UpdateRequest, string) (*models.User, error)
 	FindByEmail(context.Context, string) (*models.User, error)
-	FindByPhone(context.Context, string) (*models.User, error)
 	FindByUUID(context.Context, string) (*models.User, error)
+	FindByIdentifier(context.Context, string, []string) (*models.User, error)
+	FindAll(context.Context, *dto.UserListRequest) ([]models.User, int64, error)
+	RevokeTokens(context.Context, string, time.Time) error
+	RevokeTokensByRoleID(context.Context, uint, time.Time) error
+	UpdateRole(context.Context, string, uint) error
+	UpdateStatus(context.Context, string, string) error
+	LockActiveByRoleID(context.Context, uint) ([]uint, error)
+	Delete(context.Context, string) error
+	UpdatePassword(context.Context, string, string) error
+	SetEmailVerifiedAt(context.Context, string, *time.Time) error
+	SetTwoFactorSecret(context.Context, string, string) error
+	EnableTwoFactor(context.Context, string, time.Time) error
+	DisableTwoFactor(context.Context, string) error
+	ConsumeTwoFactorStep(context.Context, string, int64) error
+	RecordLoginFailure(context.Context, string, int, time.Time) (*models.User, error)
+	ResetLoginFailures(context.Context, string) error
 }
 
 func NewUserRepository(db *gorm.DB) IUserRepository {
@@ -33,15 +53,18 @@ func (r *UserRepository) Register(ctx context.Context, req *dto.RegisterRequest)
 	user := models.User{
 		UUID:     uuid.New(),
 		Name:     req.Name,
+		Username: req.Username,
 		Email:    req.Email,
 		Password: req.Password,
 		Phone:    req.Phone,
 		RoleID:   req.RoleID,
+		Status:   constants.UserStatusActive,
+		Locale:   req.Locale,
 	}
 
 	err := r.db.WithContext(ctx).Create(&user).Error
 	if err != nil {
-		return nil, commonErr.WrapError(constantErr.ErrSQLError)
+		return nil, translateError(ctx, err)
 	}
 
 	return &user, nil
@@ -50,14 +73,16 @@ func (r *UserRepository) Register(ctx context.Context, req *dto.RegisterRequest)
 func (r *UserRepository) Update(ctx context.Context, req *dto.UpdateRequest, uuid string) (*models.User, error) {
 	user := models.User{
 		Name:     req.Name,
+		Username: req.Username,
 		Email:    req.Email,
 		Password: *req.Password,
 		Phone:    req.Phone,
+		Locale:   req.Locale,
 	}
 
 	err := r.db.WithContext(ctx).Where("uuid = ?", uuid).Updates(&user).Error
 	if err != nil {
-		return nil, commonErr.WrapError(constantErr.ErrSQLError)
+		return nil, translateError(ctx, err)
 	}
 
 	return &user, nil
@@ -66,41 +91,349 @@ func (r *UserRepository) Update(ctx context.Context, req *dto.UpdateRequest, uui
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
 
-	err := r.db.WithContext(ctx).Preload("Role").Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Preload("Role").Where("LOWER(email) = LOWER(?)", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, constantErr.ErrUserNotFound
 		}
-		return nil, commonErr.WrapError(constantErr.ErrSQLError)
+		return nil, commonErr.WrapSQLError(ctx, err)
 	}
 
 	return &user, nil
 }
 
-func (r *UserRepository) FindByPhone(ctx context.Context, phone string) (*models.User, error) {
+func (r *UserRepository) FindByUUID(ctx context.Context, uuid string) (*models.User, error) {
 	var user models.User
 
-	err := r.db.WithContext(ctx).Preload("Role").Where("phone = ?", phone).First(&user).Error
+	err := r.db.WithContext(ctx).Preload("Role").Where("uuid = ?", uuid).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, constantErr.ErrUserNotFound
 		}
-		return nil, commonErr.WrapError(constantErr.ErrSQLError)
+		return nil, commonErr.WrapSQLError(ctx, err)
 	}
 
 	return &user, nil
 }
 
-func (r *UserRepository) FindByUUID(ctx context.Context, uuid string) (*models.User, error) {
-	var user models.User
+// FindByIdentifier looks a user up by any of the given values of one login
+// identifier. Emails and usernames are compared case-insensitively, so their
+// values must already be lower case. Values that match more than one account,
+// such as a phone stored in two formats, give ErrAmbiguousLogin.
+func (r *UserRepository) FindByIdentifier(ctx context.Context, identifierType string, values []string) (*models.User, error) {
+	var (
+		users  []models.User
+		column string
+	)
 
-	err := r.db.WithContext(ctx).Preload("Role").Where("uuid = ?", uuid).First(&user).Error
+	switch identifierType {
+	case constants.IdentifierEmail:
+		column = "LOWER(email)"
+	case constants.IdentifierPhone:
+		column = "phone"
+	case constants.IdentifierUsername:
+		column = "LOWER(username)"
+	default:
+		return nil, constantErr.ErrUserNotFound
+	}
+
+	err := r.db.WithContext(ctx).Preload("Role").Where(column+" IN ?", values).Limit(2).Find(&users).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, constantErr.ErrUserNotFound
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, constantErr.ErrUserNotFound
+	case 1:
+		return &users[0], nil
+	}
+
+	return nil, constantErr.ErrAmbiguousLogin
+}
+
+// uniqueErrors maps the unique indexes on users to the error reported when a
+// write collides with another account.
+var uniqueErrors = map[string]error{
+	"idx_users_email":    constantErr.ErrEmailExists,
+	"idx_users_phone":    constantErr.ErrPhoneExists,
+	"idx_users_username": constantErr.ErrUsernameExists,
+}
+
+func translateError(ctx context.Context, err error) error {
+	if mapped, ok := uniqueErrors[commonErr.UniqueConstraint(err)]; ok {
+		return mapped
+	}
+
+	return commonErr.WrapSQLError(ctx, err)
+}
+
+var sortColumns = map[string]string{
+	"created_at": "created_at",
+	"name":       "name",
+	"email":      "email",
+}
+
+// FindAll returns one page of users. With a cursor it seeks past req.After and
+// fetches one extra row so the caller can tell whether more pages follow; the
+// total is only counted for offset pagination, where it is needed for the meta.
+func (r *UserRepository) FindAll(ctx context.Context, req *dto.UserListRequest) ([]models.User, int64, error) {
+	var (
+		users []models.User
+		total int64
+	)
+
+	query := r.db.WithContext(ctx).Model(&models.User{})
+
+	if req.Role != "" {
+		query = query.Where("role_id IN (?)", r.db.Model(&models.Role{}).Select("id").Where("UPPER(code) = ?", strings.ToUpper(req.Role)))
+	}
+	if req.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *req.CreatedFrom)
+	}
+	if req.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *req.CreatedTo)
+	}
+	if req.Verified != nil {
+		if *req.Verified {
+			query = query.Where("email_verified_at IS NOT NULL")
+		} else {
+			query = query.Where("email_verified_at IS NULL")
+		}
+	}
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.Search != "" {
+		pattern := "%" + escapeLike(req.Search) + "%"
+		query = query.Where("(name ILIKE ? OR email ILIKE ? OR phone ILIKE ? OR username ILIKE ?)", pattern, pattern, pattern, pattern)
+	}
+
+	// Start a new session so the count and the page query are built from the
+	// same filters independently of each other.
+	query = query.Session(&gorm.Session{})
+	column := sortColumns[req.Sort]
+	direction := strings.ToUpper(req.Order)
+
+	if req.After == nil {
+		err := query.Count(&total).Error
+		if err != nil {
+			return nil, 0, commonErr.WrapSQLError(ctx, err)
+		}
+
+		query = query.Offset((req.Page - 1) * req.Limit).Limit(req.Limit)
+	} else {
+		comparator := ">"
+		if direction == "DESC" {
+			comparator = "<"
+		}
+
+		var value any = req.After.Value
+		if column == "created_at" {
+			after, err := time.Parse(time.RFC3339Nano, req.After.Value)
+			if err != nil {
+				return nil, 0, constantErr.ErrInvalidCursor
+			}
+			value = after
 		}
-		return nil, commonErr.WrapError(constantErr.ErrSQLError)
+
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparator), value, req.After.ID).Limit(req.Limit + 1)
+	}
+
+	err := query.Preload("Role").Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).Find(&users).Error
+	if err != nil {
+		return nil, 0, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return users, total, nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r *UserRepository) RevokeTokens(ctx context.Context, uuid string, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Update("tokens_revoked_at", revokedAt).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RevokeTokensByRoleID(ctx context.Context, roleID uint, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("role_id = ?", roleID).Update("tokens_revoked_at", revokedAt).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, uuid string, roleID uint) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Update("role_id", roleID).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateStatus(ctx context.Context, uuid string, status string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Update("status", status).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+// LockActiveByRoleID returns the IDs of the active users holding the role and
+// locks their rows until the surrounding transaction ends. Rows are locked in
+// ID order so concurrent callers queue instead of deadlocking.
+func (r *UserRepository) LockActiveByRoleID(ctx context.Context, roleID uint) ([]uint, error) {
+	var ids []uint
+
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role_id = ? AND status = ?", roleID, constants.UserStatusActive).
+		Order("id").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, commonErr.WrapSQLError(ctx, err)
+	}
+
+	return ids, nil
+}
+
+// Delete soft-deletes the user; the row is kept but no longer found by any
+// other query.
+func (r *UserRepository) Delete(ctx context.Context, uuid string) error {
+	err := r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.User{}).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, uuid string, password string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Update("password", password).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetEmailVerifiedAt(ctx context.Context, uuid string, verifiedAt *time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Update("email_verified_at", verifiedAt).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+// SetTwoFactorSecret stores a pending secret; two-factor stays disabled until
+// EnableTwoFactor is called after the user proved they can generate codes.
+func (r *UserRepository) SetTwoFactorSecret(ctx context.Context, uuid string, secret string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Updates(map[string]any{
+		"two_factor_secret":     secret,
+		"two_factor_enabled_at": nil,
+	}).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) EnableTwoFactor(ctx context.Context, uuid string, enabledAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Update("two_factor_enabled_at", enabledAt).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+// DisableTwoFactor turns two-factor off and forgets the secret, so it has to
+// be set up from scratch to be used again.
+func (r *UserRepository) DisableTwoFactor(ctx context.Context, uuid string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Updates(map[string]any{
+		"two_factor_secret":     "",
+		"two_factor_enabled_at": nil,
+	}).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
+
+// ConsumeTwoFactorStep records the time step of an accepted code. It fails with
+// ErrInvalidTwoFactorCode when the step is not newer than the last one used,
+// which stops a code from being replayed within its validity window.
+func (r *UserRepository) ConsumeTwoFactorStep(ctx context.Context, uuid string, step int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("uuid = ? AND two_factor_last_used_step < ?", uuid, step).
+		Update("two_factor_last_used_step", step)
+	if result.Error != nil {
+		return commonErr.WrapSQLError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return constantErr.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// RecordLoginFailure counts a failed attempt and, once maxAttempts is reached,
+// locks the account until lockedUntil. Both happen in one statement so
+// concurrent failures cannot slip past the threshold. A lock that has expired
+// starts a new count, otherwise every later mistake would lock again at once.
+func (r *UserRepository) RecordLoginFailure(ctx context.Context, uuid string, maxAttempts int, lockedUntil time.Time) (*models.User, error) {
+	var user models.User
+
+	now := time.Now()
+	attempts := gorm.Expr("CASE WHEN locked_until IS NOT NULL AND locked_until <= CAST(? AS timestamptz) THEN 1 ELSE failed_login_attempts + 1 END", now)
+
+	result := r.db.WithContext(ctx).
+		Model(&user).
+		Clauses(clause.Returning{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"failed_login_attempts": attempts,
+			"last_failed_login_at":  now,
+			"locked_until": gorm.Expr(
+				"CASE WHEN (?) >= ? THEN CAST(? AS timestamptz) WHEN locked_until <= CAST(? AS timestamptz) THEN NULL ELSE locked_until END",
+				attempts, maxAttempts, lockedUntil, now,
+			),
+		})
+	if result.Error != nil {
+		return nil, commonErr.WrapSQLError(ctx, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, constantErr.ErrUserNotFound
 	}
 
 	return &user, nil
 }
+
+func (r *UserRepository) ResetLoginFailures(ctx context.Context, uuid string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("uuid = ?", uuid).Updates(map[string]any{
+		"failed_login_attempts": 0,
+		"last_failed_login_at":  nil,
+		"locked_until":          nil,
+	}).Error
+	if err != nil {
+		return commonErr.WrapSQLError(ctx, err)
+	}
+
+	return nil
+}
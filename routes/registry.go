@@ -2,6 +2,7 @@ package routes
 
 import (
 	"user-service/controllers"
+	roleRoutes "user-service/routes/role"
 	routes "user-service/routes/user"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +25,11 @@ func (r *Registry) userRoute() routes.IUserRoute {
 	return routes.NewUserRoute(r.controller, r.group)
 }
 
+func (r *Registry) roleRoute() roleRoutes.IRoleRoute {
+	return roleRoutes.NewRoleRoute(r.controller, r.group)
+}
+
 func (r *Registry) Serve() {
 	r.userRoute().Run()
+	r.roleRoute().Run()
 }
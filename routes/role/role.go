@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"user-service/constants"
+	"user-service/controllers"
+	"user-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+}
+
+type IRoleRoute interface {
+	Run()
+}
+
+func NewRoleRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup) IRoleRoute {
+	return &RoleRoute{controller: controller, group: group}
+}
+
+func (r *RoleRoute) Run() {
+	group := r.group.Group("/roles", middlewares.Authenticate())
+	group.GET("", middlewares.RequirePermission(constants.PermissionRolesRead), r.controller.GetRoleController().FindAll)
+	group.GET("/:id", middlewares.RequirePermission(constants.PermissionRolesRead), r.controller.GetRoleController().FindByID)
+	group.POST("", middlewares.RequirePermission(constants.PermissionRolesWrite), r.controller.GetRoleController().Create)
+	group.PUT("/:id", middlewares.RequirePermission(constants.PermissionRolesWrite), r.controller.GetRoleController().Update)
+	group.DELETE("/:id", middlewares.RequirePermission(constants.PermissionRolesWrite), r.controller.GetRoleController().Delete)
+}
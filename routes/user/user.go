@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"user-service/constants"
 	"user-service/controllers"
 	"user-service/middlewares"
 
@@ -23,8 +24,29 @@ func NewUserRoute(controller controllers.IControllerRegistry, group *gin.RouterG
 func (r *UserRoute) Run() {
 	group := r.group.Group("/auth")
 	group.GET("/user", middlewares.Authenticate(), r.controller.GetUserController().GetUserLogin)
-	group.GET("/:uuid", middlewares.Authenticate(), r.controller.GetUserController().GetUserByUUID)
+	group.GET("/:uuid", middlewares.Authenticate(), middlewares.AuthorizeSelf("uuid", constants.PermissionUsersRead), r.controller.GetUserController().GetUserByUUID)
 	group.POST("/login", r.controller.GetUserController().Login)
+	group.POST("/refresh", r.controller.GetUserController().RefreshToken)
+	group.POST("/logout", middlewares.Authenticate(), r.controller.GetUserController().Logout)
+	group.POST("/logout-all", middlewares.Authenticate(), r.controller.GetUserController().LogoutAll)
 	group.POST("/register", r.controller.GetUserController().Register)
-	group.PUT("/:uuid", middlewares.Authenticate(), r.controller.GetUserController().Update)
+	group.POST("/password/forgot", r.controller.GetUserController().ForgotPassword)
+	group.POST("/password/reset", r.controller.GetUserController().ResetPassword)
+	group.POST("/email/verify", r.controller.GetUserController().VerifyEmail)
+	group.POST("/email/resend", r.controller.GetUserController().ResendVerification)
+	group.POST("/2fa/setup", middlewares.Authenticate(), r.controller.GetUserController().SetupTwoFactor)
+	group.POST("/2fa/confirm", middlewares.Authenticate(), r.controller.GetUserController().ConfirmTwoFactor)
+	group.POST("/2fa/enroll", r.controller.GetUserController().EnrollTwoFactor)
+	group.POST("/2fa/verify", r.controller.GetUserController().VerifyTwoFactor)
+	group.POST("/close-account", middlewares.Authenticate(), r.controller.GetUserController().CloseAccount)
+	group.PUT("/:uuid", middlewares.Authenticate(), middlewares.AuthorizeSelf("uuid", constants.PermissionUsersWrite), r.controller.GetUserController().Update)
+
+	users := r.group.Group("/users", middlewares.Authenticate())
+	users.GET("", middlewares.RequirePermission(constants.PermissionUsersRead), r.controller.GetUserController().ListUsers)
+	users.DELETE("/:uuid/2fa", middlewares.RequirePermission(constants.PermissionUsersWrite), r.controller.GetUserController().DisableTwoFactor)
+	users.POST("/:uuid/unlock", middlewares.RequirePermission(constants.PermissionUsersWrite), r.controller.GetUserController().UnlockUser)
+	users.POST("/:uuid/suspend", middlewares.RequirePermission(constants.PermissionUsersWrite), r.controller.GetUserController().SuspendUser)
+	users.POST("/:uuid/reactivate", middlewares.RequirePermission(constants.PermissionUsersWrite), r.controller.GetUserController().ReactivateUser)
+	users.DELETE("/:uuid", middlewares.RequirePermission(constants.PermissionUsersWrite), r.controller.GetUserController().DeleteUser)
+	users.PUT("/:uuid/role", middlewares.RequirePermission(constants.PermissionUsersWrite, constants.PermissionRolesWrite), r.controller.GetUserController().UpdateRole)
 }
@@ -1,22 +1,36 @@
 package services
 
 import (
+	"user-service/clients"
 	"user-service/repositories"
+	revocationServices "user-service/services/revocation"
+	roleServices "user-service/services/role"
 	services "user-service/services/user"
 )
 
 type Registry struct {
 	repository repositories.IRepositoryRegistry
+	client     clients.IClientRegistry
 }
 
 type IServiceRegistry interface {
 	GetUser() services.IUserService
+	GetRole() roleServices.IRoleService
+	GetRevocation() revocationServices.IRevocationService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry) IServiceRegistry {
-	return &Registry{repository: repository}
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, client clients.IClientRegistry) IServiceRegistry {
+	return &Registry{repository: repository, client: client}
 }
 
 func (r *Registry) GetUser() services.IUserService {
-	return services.NewUserService(r.repository)
+	return services.NewUserService(r.repository, r.client)
+}
+
+func (r *Registry) GetRole() roleServices.IRoleService {
+	return roleServices.NewRoleService(r.repository)
+}
+
+func (r *Registry) GetRevocation() revocationServices.IRevocationService {
+	return revocationServices.NewRevocationService(r.repository)
 }
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"time"
+	"user-service/config"
+	"user-service/domain/models"
+	"user-service/repositories"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const defaultCacheTTL = 30 * time.Second
+
+// The caches live at package level because the service registry hands out a
+// new RevocationService on every call; they must survive across requests.
+var (
+	revokedTokens = cache.New(defaultCacheTTL, 10*time.Minute)
+	userCutoffs   = cache.New(defaultCacheTTL, 10*time.Minute)
+)
+
+type RevocationService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IRevocationService interface {
+	IsRevoked(ctx context.Context, jti, userUUID string, issuedAt time.Time) (bool, error)
+	RevokeToken(ctx context.Context, jti string, userID uint, expiresAt time.Time) error
+	RevokeAll(ctx context.Context, userUUID string) error
+	RevokeAllByRole(ctx context.Context, roleID uint) error
+}
+
+func NewRevocationService(repository repositories.IRepositoryRegistry) IRevocationService {
+	return &RevocationService{repository: repository}
+}
+
+// IsRevoked reports whether the token identified by jti was logged out, or was
+// issued before the owner last logged out of every session. Negative answers are
+// cached for revocationCacheTTLSecond, so a revocation made on another replica
+// takes at most that long to be honoured here.
+//
+// issuedAt comes from the iat claim, which has whole seconds, so it is compared
+// with the cutoff truncated to its second: every token issued in the second of
+// the cutoff counts as revoked. That errs on the safe side; a token from a new
+// login is only accepted from the following second on.
+func (s *RevocationService) IsRevoked(ctx context.Context, jti, userUUID string, issuedAt time.Time) (bool, error) {
+	revoked, err := s.isTokenRevoked(ctx, jti)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	cutoff, err := s.userCutoff(ctx, userUUID)
+	if err != nil {
+		return false, err
+	}
+
+	return !cutoff.IsZero() && !issuedAt.After(cutoff.Truncate(time.Second)), nil
+}
+
+func (s *RevocationService) RevokeToken(ctx context.Context, jti string, userID uint, expiresAt time.Time) error {
+	err := s.repository.GetRevokedToken().Create(ctx, &models.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	revokedTokens.Set(jti, true, time.Until(expiresAt))
+
+	return nil
+}
+
+// RevokeAll revokes every token of the user issued up to now; see IsRevoked
+// for how the cutoff is compared.
+func (s *RevocationService) RevokeAll(ctx context.Context, userUUID string) error {
+	now := time.Now()
+
+	err := s.repository.GetUser().RevokeTokens(ctx, userUUID, now)
+	if err != nil {
+		return err
+	}
+
+	userCutoffs.Set(userUUID, now, cacheTTL())
+
+	return nil
+}
+
+// RevokeAllByRole revokes every token of the users holding the role, as
+// RevokeAll does for one user. The cached cutoffs of this replica are dropped;
+// other replicas pick the new cutoff up within revocationCacheTTLSecond.
+func (s *RevocationService) RevokeAllByRole(ctx context.Context, roleID uint) error {
+	now := time.Now()
+
+	err := s.repository.GetUser().RevokeTokensByRoleID(ctx, roleID, now)
+	if err != nil {
+		return err
+	}
+
+	userCutoffs.Flush()
+
+	return nil
+}
+
+func (s *RevocationService) isTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if value, found := revokedTokens.Get(jti); found {
+		return value.(bool), nil
+	}
+
+	revoked, err := s.repository.GetRevokedToken().ExistsByJTI(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	ttl := cacheTTL()
+	if revoked {
+		ttl = time.Duration(config.Config.JwtExpirationTime) * time.Minute
+	}
+	revokedTokens.Set(jti, revoked, ttl)
+
+	return revoked, nil
+}
+
+func (s *RevocationService) userCutoff(ctx context.Context, userUUID string) (time.Time, error) {
+	if value, found := userCutoffs.Get(userUUID); found {
+		return value.(time.Time), nil
+	}
+
+	user, err := s.repository.GetUser().FindByUUID(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var cutoff time.Time
+	if user.TokensRevokedAt != nil {
+		cutoff = *user.TokensRevokedAt
+	}
+	userCutoffs.Set(userUUID, cutoff, cacheTTL())
+
+	return cutoff, nil
+}
+
+func cacheTTL() time.Duration {
+	if config.Config.RevocationCacheTTLSecond > 0 {
+		return time.Duration(config.Config.RevocationCacheTTLSecond) * time.Second
+	}
+
+	return defaultCacheTTL
+}
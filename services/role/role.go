@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+	revocationServices "user-service/services/revocation"
+
+	errConstants "user-service/constants/error"
+)
+
+type RoleService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IRoleService interface {
+	Create(context.Context, *dto.RoleRequest) (*dto.RoleResponse, error)
+	Update(context.Context, uint, *dto.RoleRequest) (*dto.RoleResponse, error)
+	Delete(context.Context, uint) error
+	FindAll(context.Context) ([]dto.RoleResponse, error)
+	FindByID(context.Context, uint) (*dto.RoleResponse, error)
+}
+
+// systemRoles are referenced by code throughout the service and must keep
+// existing under the same code.
+var systemRoles = []string{constants.AdminCode, constants.CustomerCode}
+
+// adminPermissions can never be taken from ADMIN; without them nobody could
+// manage users or roles any more, including granting them back.
+var adminPermissions = []string{constants.PermissionUsersWrite, constants.PermissionRolesWrite}
+
+func NewRoleService(repository repositories.IRepositoryRegistry) IRoleService {
+	return &RoleService{repository: repository}
+}
+
+func (r *RoleService) Create(ctx context.Context, req *dto.RoleRequest) (*dto.RoleResponse, error) {
+	_, err := r.repository.GetRole().FindByCode(ctx, req.Code)
+	if err == nil {
+		return nil, errConstants.ErrRoleExists
+	}
+	if !errors.Is(err, errConstants.ErrRoleNotFound) {
+		return nil, err
+	}
+
+	permissionIDs := []uint{}
+	if req.Permissions != nil {
+		permissionIDs, err = r.resolvePermissions(ctx, *req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	role, err := r.repository.GetRole().Create(ctx, &models.Role{
+		Code:              req.Code,
+		Name:              req.Name,
+		TwoFactorRequired: req.TwoFactorRequired,
+	}, permissionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toResponse(ctx, role)
+}
+
+func (r *RoleService) Update(ctx context.Context, id uint, req *dto.RoleRequest) (*dto.RoleResponse, error) {
+	role, err := r.repository.GetRole().FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.Code != req.Code {
+		if slices.Contains(systemRoles, role.Code) {
+			return nil, errConstants.ErrRoleProtected
+		}
+
+		_, err = r.repository.GetRole().FindByCode(ctx, req.Code)
+		if err == nil {
+			return nil, errConstants.ErrRoleExists
+		}
+		if !errors.Is(err, errConstants.ErrRoleNotFound) {
+			return nil, err
+		}
+	}
+
+	// Tokens carry the role code and its permissions, so changing either
+	// ends the sessions of the role's users.
+	revoke := role.Code != req.Code
+
+	var permissionIDs []uint
+	if req.Permissions != nil {
+		if role.Code == constants.AdminCode {
+			for _, permission := range adminPermissions {
+				if !slices.Contains(*req.Permissions, permission) {
+					return nil, errConstants.ErrRoleProtected
+				}
+			}
+		}
+
+		permissionIDs, err = r.resolvePermissions(ctx, *req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := r.repository.GetPermission().FindCodesByRoleID(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		revoke = revoke || !slices.Equal(slices.Sorted(slices.Values(current)), slices.Compact(slices.Sorted(slices.Values(*req.Permissions))))
+	}
+
+	role.Code = req.Code
+	role.Name = req.Name
+	role.TwoFactorRequired = req.TwoFactorRequired
+
+	err = r.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		role, err = repository.GetRole().Update(ctx, role, permissionIDs)
+		if err != nil {
+			return err
+		}
+
+		if !revoke {
+			return nil
+		}
+
+		err = repository.GetRefreshToken().RevokeByRoleID(ctx, role.ID)
+		if err != nil {
+			return err
+		}
+
+		return revocationServices.NewRevocationService(repository).RevokeAllByRole(ctx, role.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toResponse(ctx, role)
+}
+
+func (r *RoleService) Delete(ctx context.Context, id uint) error {
+	role, err := r.repository.GetRole().FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(systemRoles, role.Code) {
+		return errConstants.ErrRoleProtected
+	}
+
+	// The foreign key from users restricts the delete anyway; counting first
+	// gives a clear error instead of a database one. Deleted accounts count
+	// too, since their rows are kept.
+	count, err := r.repository.GetRole().CountUsers(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errConstants.ErrRoleInUse
+	}
+
+	return r.repository.GetRole().Delete(ctx, role.ID)
+}
+
+func (r *RoleService) FindAll(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := r.repository.GetRole().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		response, err := r.toResponse(ctx, &role)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, *response)
+	}
+
+	return data, nil
+}
+
+func (r *RoleService) FindByID(ctx context.Context, id uint) (*dto.RoleResponse, error) {
+	role, err := r.repository.GetRole().FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toResponse(ctx, role)
+}
+
+func (r *RoleService) resolvePermissions(ctx context.Context, codes []string) ([]uint, error) {
+	codes = slices.Compact(slices.Sorted(slices.Values(codes)))
+	if len(codes) == 0 {
+		return []uint{}, nil
+	}
+
+	permissions, err := r.repository.GetPermission().FindByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permissions) != len(codes) {
+		return nil, errConstants.ErrPermissionNotFound
+	}
+
+	ids := make([]uint, 0, len(permissions))
+	for _, permission := range permissions {
+		ids = append(ids, permission.ID)
+	}
+
+	return ids, nil
+}
+
+func (r *RoleService) toResponse(ctx context.Context, role *models.Role) (*dto.RoleResponse, error) {
+	permissions, err := r.repository.GetPermission().FindCodesByRoleID(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &dto.RoleResponse{
+		ID:                role.ID,
+		Code:              role.Code,
+		Name:              role.Name,
+		TwoFactorRequired: role.TwoFactorRequired,
+		Permissions:       permissions,
+		CreatedAt:         role.CreatedAt,
+	}, nil
+}
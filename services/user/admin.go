@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	errConstants "user-service/constants/error"
+)
+
+const defaultListLimit = 20
+
+// ListUsers supports both offset pagination (page, limit) and cursor
+// pagination. Every page that has a successor carries a nextCursor, so a
+// client can start with the first page and keep following cursors.
+func (u *UserService) ListUsers(ctx context.Context, req *dto.UserListRequest) ([]dto.UserResponse, *dto.PaginationMeta, error) {
+	var err error
+
+	if req.Limit == 0 {
+		req.Limit = defaultListLimit
+	}
+	if req.Sort == "" {
+		req.Sort = "created_at"
+	}
+	if req.Order == "" {
+		req.Order = "desc"
+	}
+	if req.Cursor != "" {
+		req.After, err = decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Sort = req.After.Sort
+		req.Order = req.After.Order
+	} else if req.Page == 0 {
+		req.Page = 1
+	}
+
+	users, total, err := u.repository.GetUser().FindAll(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := &dto.PaginationMeta{Limit: req.Limit}
+	if req.After != nil {
+		if len(users) > req.Limit {
+			users = users[:req.Limit]
+			meta.HasMore = true
+		}
+	} else {
+		totalPages := int(math.Ceil(float64(total) / float64(req.Limit)))
+		meta.Page = req.Page
+		meta.Total = &total
+		meta.TotalPages = &totalPages
+		meta.HasMore = req.Page < totalPages
+	}
+
+	if meta.HasMore && len(users) > 0 {
+		meta.NextCursor = encodeCursor(req.Sort, req.Order, &users[len(users)-1])
+	}
+
+	data := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		data = append(data, dto.UserResponse{
+			UUID:            user.UUID,
+			Name:            user.Name,
+			Username:        user.Username,
+			Email:           user.Email,
+			Role:            strings.ToLower(user.Role.Code),
+			Phone:           user.Phone,
+			Status:          user.Status,
+			Locale:          user.Locale,
+			EmailVerifiedAt: user.EmailVerifiedAt,
+			CreatedAt:       user.CreatedAt,
+		})
+	}
+
+	return data, meta, nil
+}
+
+// UpdateRole moves the user to another role. The user's sessions are revoked
+// because their tokens still carry the old role and permissions.
+func (u *UserService) UpdateRole(ctx context.Context, uuid string, req *dto.UpdateUserRoleRequest) (*dto.UserResponse, error) {
+	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := u.repository.GetRole().FindByID(ctx, req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.RoleID != role.ID {
+		err = u.repository.GetUser().UpdateRole(ctx, uuid, role.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = u.revokeAllSessions(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.UserResponse{
+		UUID:            user.UUID,
+		Name:            user.Name,
+		Username:        user.Username,
+		Email:           user.Email,
+		Role:            strings.ToLower(role.Code),
+		Phone:           user.Phone,
+		Locale:          user.Locale,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+	}, nil
+}
+
+func encodeCursor(sort, order string, user *models.User) string {
+	cursor := dto.UserCursor{Sort: sort, Order: order, ID: user.ID}
+	switch sort {
+	case "name":
+		cursor.Value = user.Name
+	case "email":
+		cursor.Value = user.Email
+	default:
+		if user.CreatedAt != nil {
+			cursor.Value = user.CreatedAt.Format(time.RFC3339Nano)
+		}
+	}
+
+	payload, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeCursor(value string) (*dto.UserCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	cursor := &dto.UserCursor{}
+	err = json.Unmarshal(payload, cursor)
+	if err != nil || cursor.ID == 0 {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	switch cursor.Sort {
+	case "created_at", "name", "email":
+	default:
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	if cursor.Order != "asc" && cursor.Order != "desc" {
+		return nil, errConstants.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/config"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+
+	errConstants "user-service/constants/error"
+)
+
+func (u *UserService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error {
+	token, err := u.repository.GetEmailVerificationToken().FindByTokenHash(ctx, hashToken(req.Token))
+	if err != nil {
+		return err
+	}
+
+	if token.UsedAt != nil {
+		return errConstants.ErrInvalidVerificationToken
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return errConstants.ErrVerificationTokenExpired
+	}
+
+	// Consuming the token and verifying the address succeed or fail together.
+	return u.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		err := repository.GetEmailVerificationToken().MarkUsed(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		return repository.GetUser().SetEmailVerifiedAt(ctx, token.User.UUID.String(), &now)
+	})
+}
+
+// ResendVerification issues a fresh verification link. Like ForgotPassword it
+// does not reveal whether the email is registered or already verified: the
+// link is sent in the background and a failure is only logged.
+func (u *UserService) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) error {
+	user, err := u.repository.GetUser().FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, errConstants.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return u.sendEmailVerification(ctx, user)
+}
+
+func (u *UserService) sendEmailVerification(ctx context.Context, user *models.User) error {
+	err := u.repository.GetEmailVerificationToken().InvalidateByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	token, err := generateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(config.Config.EmailVerificationExpirationTime) * time.Minute)
+	_, err = u.repository.GetEmailVerificationToken().Create(ctx, &models.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	link := buildLink(config.Config.EmailVerificationURL, token)
+	notify(ctx, "email verification", func(ctx context.Context) error {
+		return u.client.GetNotifier().SendEmailVerification(ctx, user, link, expiresAt)
+	})
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"user-service/config"
+	"user-service/constants"
+)
+
+var (
+	e164Pattern       = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	localPhonePattern = regexp.MustCompile(`^0[0-9]{7,14}$`)
+	phoneSeparators   = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+)
+
+// resolveIdentifier works out whether a login identifier is an email, a phone
+// number or a username and returns the values to look it up by. Phones are
+// matched in E.164 and in the local trunk format, because accounts created
+// before numbers were normalised may hold either.
+func resolveIdentifier(identifier string) (string, []string) {
+	identifier = strings.TrimSpace(identifier)
+
+	if strings.Contains(identifier, "@") {
+		return constants.IdentifierEmail, []string{strings.ToLower(identifier)}
+	}
+
+	if phones := phoneCandidates(identifier); len(phones) > 0 {
+		return constants.IdentifierPhone, phones
+	}
+
+	return constants.IdentifierUsername, []string{strings.ToLower(identifier)}
+}
+
+// phoneValues returns the formats a stored phone may take, or phone itself
+// when it is not a recognisable number.
+func phoneValues(phone string) []string {
+	if candidates := phoneCandidates(phone); len(candidates) > 0 {
+		return candidates
+	}
+
+	return []string{phone}
+}
+
+func phoneCandidates(value string) []string {
+	phone := phoneSeparators.Replace(value)
+	countryCode := config.Config.DefaultPhoneCountryCode
+	if countryCode == "" {
+		countryCode = "62"
+	}
+
+	switch {
+	case e164Pattern.MatchString(phone):
+		candidates := []string{phone}
+		if strings.HasPrefix(phone, "+"+countryCode) {
+			candidates = append(candidates, "0"+strings.TrimPrefix(phone, "+"+countryCode))
+		}
+		return candidates
+	case localPhonePattern.MatchString(phone):
+		return []string{"+" + countryCode + phone[1:], phone}
+	}
+
+	return nil
+}
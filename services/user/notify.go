@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"time"
+	"user-service/common/logger"
+)
+
+// notifyTimeout bounds a background delivery, which no longer has the request
+// deadline to stop it.
+const notifyTimeout = time.Minute
+
+// notify runs send in the background and only logs a failure. Callers answer
+// the same way whether or not a message went out, so neither the result nor
+// the time the mail server takes tells a registered address from another.
+func notify(ctx context.Context, what string, send func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+
+	go func() {
+		defer cancel()
+
+		err := send(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to send %s: %v", what, err)
+		}
+	}()
+}
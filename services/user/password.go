@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+	"user-service/common/tracing"
+	"user-service/config"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+
+	errConstants "user-service/constants/error"
+)
+
+// ForgotPassword sends a reset link when the email belongs to an account. It
+// succeeds silently for unknown emails, and the link goes out in the
+// background, so the endpoint cannot be used to find out who is registered.
+func (u *UserService) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+	user, err := u.repository.GetUser().FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, errConstants.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	err = u.repository.GetPasswordResetToken().InvalidateByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	token, err := generateRandomToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(time.Duration(config.Config.PasswordResetExpirationTime) * time.Minute)
+	_, err = u.repository.GetPasswordResetToken().Create(ctx, &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	link := buildLink(config.Config.PasswordResetURL, token)
+	notify(ctx, "password reset", func(ctx context.Context) error {
+		return u.client.GetNotifier().SendPasswordReset(ctx, user, link, expiresAt)
+	})
+
+	return nil
+}
+
+// ResetPassword sets a new password from a reset token and ends every
+// session of the user, so whoever had access before the reset loses it.
+func (u *UserService) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	if req.Password != req.ConfirmPassword {
+		return errConstants.ErrPasswordDoesMatch
+	}
+
+	token, err := u.repository.GetPasswordResetToken().FindByTokenHash(ctx, hashToken(req.Token))
+	if err != nil {
+		return err
+	}
+
+	if token.UsedAt != nil {
+		return errConstants.ErrInvalidResetToken
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return errConstants.ErrResetTokenExpired
+	}
+
+	hashedPassword, err := hashPassword(ctx, req.Password)
+	if err != nil {
+		return err
+	}
+
+	// Consuming the token, setting the password and ending the sessions
+	// succeed or fail together.
+	return u.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		err := repository.GetPasswordResetToken().MarkUsed(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+
+		err = repository.GetUser().UpdatePassword(ctx, token.User.UUID.String(), string(hashedPassword))
+		if err != nil {
+			return err
+		}
+
+		return revokeAllSessions(ctx, repository, token.User.UUID.String())
+	})
+}
+
+// SetPassword replaces the password of a user without a reset token and ends
+// every session, as ResetPassword does. It is meant for operators.
+func (u *UserService) SetPassword(ctx context.Context, uuid string, password string) error {
+	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := hashPassword(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		err := repository.GetUser().UpdatePassword(ctx, user.UUID.String(), string(hashedPassword))
+		if err != nil {
+			return err
+		}
+
+		return revokeAllSessions(ctx, repository, user.UUID.String())
+	})
+}
+
+// hashPassword and comparePassword run bcrypt in their own spans; they are
+// deliberately slow and worth seeing in a trace.
+func hashPassword(ctx context.Context, password string) ([]byte, error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword")
+	defer span.End()
+
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+func comparePassword(ctx context.Context, hash string, password string) error {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
+
+func buildLink(base, token string) string {
+	link, err := url.Parse(base)
+	if err != nil {
+		return base + "?token=" + url.QueryEscape(token)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"time"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+
+	"github.com/patrickmn/go-cache"
+
+	errConstants "user-service/constants/error"
+)
+
+const defaultStatusCacheTTL = 30 * time.Second
+
+// accountStates caches the account state looked up on every authenticated
+// request. Changes made here drop the entry; changes made on another replica
+// are picked up once the entry expires.
+var accountStates = cache.New(defaultStatusCacheTTL, 10*time.Minute)
+
+func statusError(status string) error {
+	switch status {
+	case constants.UserStatusSuspended:
+		return errConstants.ErrAccountSuspended
+	case constants.UserStatusDeactivated:
+		return errConstants.ErrAccountClosed
+	}
+
+	return nil
+}
+
+func checkStatus(user *models.User) error {
+	if user.ID == 0 {
+		return errConstants.ErrUserNotFound
+	}
+
+	return statusError(user.Status)
+}
+
+// CheckStatus returns the stored state of the account, or an error unless the
+// user exists and is active.
+func (u *UserService) CheckStatus(ctx context.Context, uuid string) (*dto.AccountState, error) {
+	if value, found := accountStates.Get(uuid); found {
+		state := value.(dto.AccountState)
+		return &state, statusError(state.Status)
+	}
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	state := dto.AccountState{Status: user.Status, Locale: user.Locale}
+	accountStates.Set(uuid, state, statusCacheTTL())
+
+	return &state, statusError(state.Status)
+}
+
+func (u *UserService) SuspendUser(ctx context.Context, uuid string) error {
+	err := checkNotSelf(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = u.withoutLastAdmin(ctx, uuid, func(repository repositories.IRepositoryRegistry) error {
+		return setStatus(ctx, repository, uuid, constants.UserStatusSuspended)
+	})
+	if err != nil {
+		return err
+	}
+
+	accountStates.Delete(uuid)
+
+	return nil
+}
+
+func (u *UserService) ReactivateUser(ctx context.Context, uuid string) error {
+	err := setStatus(ctx, u.repository, uuid, constants.UserStatusActive)
+	if err != nil {
+		return err
+	}
+
+	accountStates.Delete(uuid)
+
+	return nil
+}
+
+func (u *UserService) DeleteUser(ctx context.Context, uuid string) error {
+	err := checkNotSelf(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = u.withoutLastAdmin(ctx, uuid, func(repository repositories.IRepositoryRegistry) error {
+		err := revokeAllSessions(ctx, repository, uuid)
+		if err != nil {
+			return err
+		}
+
+		return repository.GetUser().Delete(ctx, uuid)
+	})
+	if err != nil {
+		return err
+	}
+
+	accountStates.Delete(uuid)
+
+	return nil
+}
+
+// checkNotSelf keeps callers from suspending or deleting their own account.
+func checkNotSelf(ctx context.Context, uuid string) error {
+	userLogin, ok := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+	if ok && userLogin.UUID.String() == uuid {
+		return errConstants.ErrForbidden
+	}
+
+	return nil
+}
+
+// withoutLastAdmin runs change, which takes the user out of service, in a
+// transaction unless the user is the last active administrator. The active
+// administrators are locked before they are counted, so two of them removing
+// each other at the same time cannot both go through.
+func (u *UserService) withoutLastAdmin(ctx context.Context, uuid string, change func(repositories.IRepositoryRegistry) error) error {
+	return u.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		user, err := repository.GetUser().FindByUUID(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		if user.Role.Code == constants.AdminCode {
+			admins, err := repository.GetUser().LockActiveByRoleID(ctx, user.RoleID)
+			if err != nil {
+				return err
+			}
+
+			if len(admins) <= 1 && slices.Contains(admins, user.ID) {
+				return errConstants.ErrLastAdmin
+			}
+		}
+
+		return change(repository)
+	})
+}
+
+// CloseAccount deactivates the account of the current user after checking
+// their password. Only an administrator can reactivate it.
+func (u *UserService) CloseAccount(ctx context.Context, req *dto.CloseAccountRequest) error {
+	userLogin := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, userLogin.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	err = comparePassword(ctx, user.Password, req.Password)
+	if err != nil {
+		return errConstants.ErrPasswordIncorrect
+	}
+
+	uuid := user.UUID.String()
+	err = u.withoutLastAdmin(ctx, uuid, func(repository repositories.IRepositoryRegistry) error {
+		return setStatus(ctx, repository, uuid, constants.UserStatusDeactivated)
+	})
+	if err != nil {
+		return err
+	}
+
+	accountStates.Delete(uuid)
+
+	return nil
+}
+
+// setStatus changes the status of the user through repository, which may be
+// bound to a transaction, and ends every session unless the account becomes
+// active.
+func setStatus(ctx context.Context, repository repositories.IRepositoryRegistry, uuid string, status string) error {
+	_, err := repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = repository.GetUser().UpdateStatus(ctx, uuid, status)
+	if err != nil {
+		return err
+	}
+
+	if status == constants.UserStatusActive {
+		return nil
+	}
+
+	return revokeAllSessions(ctx, repository, uuid)
+}
+
+func statusCacheTTL() time.Duration {
+	if config.Config.RevocationCacheTTLSecond > 0 {
+		return time.Duration(config.Config.RevocationCacheTTLSecond) * time.Second
+	}
+
+	return defaultStatusCacheTTL
+}
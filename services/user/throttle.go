@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+	"user-service/config"
+	"user-service/domain/models"
+
+	"github.com/patrickmn/go-cache"
+
+	errConstants "user-service/constants/error"
+)
+
+type loginFailures struct {
+	count        int
+	lastFailedAt time.Time
+}
+
+// Failures per client are kept in memory only; they complement the
+// per-account counters stored on the user, which work across replicas. The
+// backoff is keyed on the IP together with the account it targets, so one
+// client guessing passwords behind a shared NAT does not slow down everyone
+// else there; the IP alone only has a flat, higher cap.
+var (
+	failuresMutex  sync.Mutex
+	ipAttempts     = cache.New(15*time.Minute, 30*time.Minute)
+	clientAttempts = cache.New(15*time.Minute, 30*time.Minute)
+)
+
+func clientKey(ip, identifier string) string {
+	return ip + "\x00" + identifier
+}
+
+func throttleConfig() config.LoginThrottle {
+	settings := config.Config.LoginThrottle
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = 5
+	}
+	if settings.LockoutMinute <= 0 {
+		settings.LockoutMinute = 15
+	}
+	if settings.BackoffBaseSecond <= 0 {
+		settings.BackoffBaseSecond = 1
+	}
+	if settings.BackoffMaxSecond <= 0 {
+		settings.BackoffMaxSecond = 60
+	}
+	if settings.IPMaxAttempts <= 0 {
+		settings.IPMaxAttempts = 100
+	}
+	if settings.IPWindowMinute <= 0 {
+		settings.IPWindowMinute = 15
+	}
+
+	return settings
+}
+
+// backoff is the wait imposed after the given number of consecutive failures:
+// base, 2*base, 4*base, ... capped at the configured maximum.
+func backoff(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+
+	settings := throttleConfig()
+	base := time.Duration(settings.BackoffBaseSecond) * time.Second
+	limit := time.Duration(settings.BackoffMaxSecond) * time.Second
+
+	if failures > 16 {
+		return limit
+	}
+
+	delay := base << (failures - 1)
+	if delay > limit {
+		return limit
+	}
+
+	return delay
+}
+
+// checkClientThrottle refuses an attempt from ip on identifier while the IP is
+// over its cap or the pair is still backing off from earlier failures.
+func checkClientThrottle(ip, identifier string) error {
+	if value, found := ipAttempts.Get(ip); found && value.(loginFailures).count >= throttleConfig().IPMaxAttempts {
+		return errConstants.ErrToManyRequest
+	}
+
+	value, found := clientAttempts.Get(clientKey(ip, identifier))
+	if !found {
+		return nil
+	}
+
+	failures := value.(loginFailures)
+	if time.Now().Before(failures.lastFailedAt.Add(backoff(failures.count))) {
+		return errConstants.ErrToManyRequest
+	}
+
+	return nil
+}
+
+func recordClientFailure(ip, identifier string) {
+	failuresMutex.Lock()
+	defer failuresMutex.Unlock()
+
+	window := time.Duration(throttleConfig().IPWindowMinute) * time.Minute
+	addFailure(ipAttempts, ip, window)
+	addFailure(clientAttempts, clientKey(ip, identifier), window)
+}
+
+func addFailure(attempts *cache.Cache, key string, window time.Duration) {
+	failures := loginFailures{}
+	if value, found := attempts.Get(key); found {
+		failures = value.(loginFailures)
+	}
+
+	failures.count++
+	failures.lastFailedAt = time.Now()
+	attempts.Set(key, failures, window)
+}
+
+// clearClientFailures forgets the failures of the pair after a successful
+// login and takes one off the IP, so regular logins from a shared address
+// wear its count down again.
+func clearClientFailures(ip, identifier string) {
+	failuresMutex.Lock()
+	defer failuresMutex.Unlock()
+
+	clientAttempts.Delete(clientKey(ip, identifier))
+
+	value, found := ipAttempts.Get(ip)
+	if !found {
+		return
+	}
+
+	failures := value.(loginFailures)
+	if failures.count <= 1 {
+		ipAttempts.Delete(ip)
+		return
+	}
+
+	failures.count--
+	ipAttempts.Set(ip, failures, time.Duration(throttleConfig().IPWindowMinute)*time.Minute)
+}
+
+func checkAccountThrottle(user *models.User) error {
+	now := time.Now()
+
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return errConstants.ErrAccountLocked
+	}
+
+	if user.LastFailedLoginAt != nil && now.Before(user.LastFailedLoginAt.Add(backoff(user.FailedLoginAttempts))) {
+		return errConstants.ErrToManyRequest
+	}
+
+	return nil
+}
+
+// recordLoginFailure books a failed attempt against the client and the account
+// and returns the error to report: ErrAccountLocked once the threshold is hit,
+// cause otherwise.
+func (u *UserService) recordLoginFailure(ctx context.Context, user *models.User, ip, identifier string, cause error) error {
+	recordClientFailure(ip, identifier)
+
+	settings := throttleConfig()
+	lockedUntil := time.Now().Add(time.Duration(settings.LockoutMinute) * time.Minute)
+
+	updated, err := u.repository.GetUser().RecordLoginFailure(ctx, user.UUID.String(), settings.MaxAttempts, lockedUntil)
+	if err != nil {
+		return err
+	}
+
+	if updated.LockedUntil != nil && time.Now().Before(*updated.LockedUntil) {
+		return errConstants.ErrAccountLocked
+	}
+
+	return cause
+}
+
+func (u *UserService) resetLoginFailures(ctx context.Context, user *models.User) error {
+	if user.FailedLoginAttempts == 0 && user.LockedUntil == nil {
+		return nil
+	}
+
+	return u.repository.GetUser().ResetLoginFailures(ctx, user.UUID.String())
+}
+
+func (u *UserService) UnlockUser(ctx context.Context, uuid string) error {
+	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.GetUser().ResetLoginFailures(ctx, user.UUID.String())
+}
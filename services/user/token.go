@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"user-service/common/keyset"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+	revocationServices "user-service/services/revocation"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	errConstants "user-service/constants/error"
+)
+
+type session struct {
+	familyID  uuid.UUID
+	deviceID  string
+	userAgent string
+	ipAddress string
+}
+
+func (u *UserService) RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	token, err := u.repository.GetRefreshToken().FindByTokenHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RotatedAt != nil {
+		// An already rotated token is being presented again, so a copy of it
+		// is in someone else's hands. Kill every token descended from the same login.
+		err = u.repository.GetRefreshToken().RevokeFamily(ctx, token.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, errConstants.ErrRefreshTokenReused
+	}
+
+	if token.RevokedAt != nil {
+		return nil, errConstants.ErrInvalidRefreshToken
+	}
+
+	if time.Now().After(token.ExpiresAt) {
+		return nil, errConstants.ErrRefreshTokenExpired
+	}
+
+	err = checkStatus(&token.User)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceID := req.DeviceID
+	if deviceID == "" {
+		deviceID = token.DeviceID
+	}
+
+	// The old token is only used up together with storing its successor, so
+	// a failed refresh can be retried without looking like reuse.
+	var response *dto.LoginResponse
+	err = u.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		err := repository.GetRefreshToken().MarkRotated(ctx, token.ID)
+		if err != nil {
+			return err
+		}
+
+		response, err = generateTokens(ctx, repository, &token.User, session{
+			familyID:  token.FamilyID,
+			deviceID:  deviceID,
+			userAgent: req.UserAgent,
+			ipAddress: req.IPAddress,
+		})
+
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errConstants.ErrRefreshTokenReused) {
+			_ = u.repository.GetRefreshToken().RevokeFamily(ctx, token.FamilyID)
+		}
+
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Logout revokes the access token of the current request together with the
+// refresh token family it was issued from.
+func (u *UserService) Logout(ctx context.Context) error {
+	claims := ctx.Value(constants.TokenClaims).(*Claims)
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, claims.Subject)
+	if err != nil {
+		return err
+	}
+
+	err = revocationServices.NewRevocationService(u.repository).RevokeToken(ctx, claims.ID, user.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return err
+	}
+
+	familyID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil
+	}
+
+	return u.repository.GetRefreshToken().RevokeFamily(ctx, familyID)
+}
+
+// LogoutAll ends every session of the current user: outstanding refresh
+// tokens are revoked and access tokens issued before now stop validating.
+func (u *UserService) LogoutAll(ctx context.Context) error {
+	claims := ctx.Value(constants.TokenClaims).(*Claims)
+
+	return u.revokeAllSessions(ctx, claims.Subject)
+}
+
+func (u *UserService) revokeAllSessions(ctx context.Context, userUUID string) error {
+	return revokeAllSessions(ctx, u.repository, userUUID)
+}
+
+// revokeAllSessions revokes the refresh tokens and access tokens of the user
+// through repository, which may be bound to a transaction.
+func revokeAllSessions(ctx context.Context, repository repositories.IRepositoryRegistry, userUUID string) error {
+	user, err := repository.GetUser().FindByUUID(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+
+	err = repository.GetRefreshToken().RevokeByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return revocationServices.NewRevocationService(repository).RevokeAll(ctx, userUUID)
+}
+
+func (u *UserService) generateTokens(ctx context.Context, user *models.User, sess session) (*dto.LoginResponse, error) {
+	return generateTokens(ctx, u.repository, user, sess)
+}
+
+// generateTokens signs an access token and stores a refresh token for the
+// session through repository, which may be bound to a transaction.
+func generateTokens(ctx context.Context, repository repositories.IRepositoryRegistry, user *models.User, sess session) (*dto.LoginResponse, error) {
+	data := loginUser(user)
+
+	// Permissions are resolved once per token so authorization checks never
+	// touch the database; changing a role's grants revokes its sessions.
+	permissions, err := repository.GetPermission().FindCodesByRoleID(ctx, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenString, tokenExpiresAt, err := generateAccessToken(data, sess.familyID, permissions, accessTokenTTL())
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := generateRandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTokenExpiresAt := time.Now().Add(time.Duration(config.Config.RefreshTokenExpirationTime) * time.Minute)
+	_, err = repository.GetRefreshToken().Create(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  sess.familyID,
+		TokenHash: hashToken(refreshToken),
+		DeviceID:  sess.deviceID,
+		UserAgent: truncate(sess.userAgent, 255),
+		IPAddress: sess.ipAddress,
+		ExpiresAt: refreshTokenExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.LoginResponse{
+		User:                  *data,
+		Token:                 tokenString,
+		TokenExpiresAt:        &tokenExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: &refreshTokenExpiresAt,
+	}
+
+	return response, nil
+}
+
+func loginUser(user *models.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		UUID:     user.UUID,
+		Name:     user.Name,
+		Username: user.Username,
+		Email:    user.Email,
+		Phone:    user.Phone,
+		Role:     strings.ToLower(user.Role.Code),
+		Locale:   user.Locale,
+	}
+}
+
+// IssueAccessToken signs an access token for the user with a custom
+// lifetime, without a refresh token or login. It exists for debugging.
+func (u *UserService) IssueAccessToken(ctx context.Context, userUUID string, ttl time.Duration) (*dto.LoginResponse, error) {
+	user, err := u.repository.GetUser().FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkStatus(user)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := u.repository.GetPermission().FindCodesByRoleID(ctx, user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := loginUser(user)
+	tokenString, tokenExpiresAt, err := generateAccessToken(data, uuid.New(), permissions, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		User:           *data,
+		Token:          tokenString,
+		TokenExpiresAt: &tokenExpiresAt,
+	}, nil
+}
+
+func accessTokenTTL() time.Duration {
+	return time.Duration(config.Config.JwtExpirationTime) * time.Minute
+}
+
+func generateAccessToken(user *dto.UserResponse, sessionID uuid.UUID, permissions []string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expirationTime := now.Add(ttl)
+
+	claims := &Claims{
+		User:        user,
+		SessionID:   sessionID.String(),
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    config.Config.AppName,
+			Subject:   user.UUID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+		},
+	}
+
+	tokenString, err := keyset.Sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expirationTime, nil
+}
+
+func generateRandomToken() (string, error) {
+	buf := make([]byte, 32)
+
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the value persisted for opaque tokens so that a leaked
+// table dump cannot be replayed against the API.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+
+	return value
+}
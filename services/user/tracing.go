@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"time"
+	"user-service/common/tracing"
+	"user-service/domain/dto"
+)
+
+// tracedUserService wraps every IUserService method in a span named after it.
+type tracedUserService struct {
+	next IUserService
+}
+
+func (t *tracedUserService) Login(ctx context.Context, req *dto.LoginRequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Login(ctx, req)
+}
+
+func (t *tracedUserService) RefreshToken(ctx context.Context, req *dto.RefreshTokenRequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.RefreshToken")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.RefreshToken(ctx, req)
+}
+
+func (t *tracedUserService) Logout(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Logout(ctx)
+}
+
+func (t *tracedUserService) LogoutAll(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.LogoutAll")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.LogoutAll(ctx)
+}
+
+func (t *tracedUserService) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ForgotPassword")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ForgotPassword(ctx, req)
+}
+
+func (t *tracedUserService) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ResetPassword(ctx, req)
+}
+
+func (t *tracedUserService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyEmail")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.VerifyEmail(ctx, req)
+}
+
+func (t *tracedUserService) ResendVerification(ctx context.Context, req *dto.ResendVerificationRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ResendVerification")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ResendVerification(ctx, req)
+}
+
+func (t *tracedUserService) SetupTwoFactor(ctx context.Context, req *dto.TwoFactorSetupRequest) (result *dto.TwoFactorSetupResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetupTwoFactor")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.SetupTwoFactor(ctx, req)
+}
+
+func (t *tracedUserService) ConfirmTwoFactor(ctx context.Context, req *dto.TwoFactorConfirmRequest) (result *dto.TwoFactorConfirmResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ConfirmTwoFactor")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ConfirmTwoFactor(ctx, req)
+}
+
+func (t *tracedUserService) EnrollTwoFactor(ctx context.Context, req *dto.TwoFactorEnrollRequest) (result *dto.TwoFactorSetupResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.EnrollTwoFactor")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.EnrollTwoFactor(ctx, req)
+}
+
+func (t *tracedUserService) VerifyTwoFactor(ctx context.Context, req *dto.TwoFactorVerifyRequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyTwoFactor")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.VerifyTwoFactor(ctx, req)
+}
+
+func (t *tracedUserService) DisableTwoFactor(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DisableTwoFactor")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.DisableTwoFactor(ctx, uuid)
+}
+
+func (t *tracedUserService) UnlockUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.UnlockUser")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.UnlockUser(ctx, uuid)
+}
+
+func (t *tracedUserService) ListUsers(ctx context.Context, req *dto.UserListRequest) (result []dto.UserResponse, meta *dto.PaginationMeta, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ListUsers(ctx, req)
+}
+
+func (t *tracedUserService) UpdateRole(ctx context.Context, uuid string, req *dto.UpdateUserRoleRequest) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateRole")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.UpdateRole(ctx, uuid, req)
+}
+
+func (t *tracedUserService) CheckStatus(ctx context.Context, uuid string) (state *dto.AccountState, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CheckStatus")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.CheckStatus(ctx, uuid)
+}
+
+func (t *tracedUserService) SuspendUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SuspendUser")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.SuspendUser(ctx, uuid)
+}
+
+func (t *tracedUserService) ReactivateUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ReactivateUser")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.ReactivateUser(ctx, uuid)
+}
+
+func (t *tracedUserService) DeleteUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.DeleteUser(ctx, uuid)
+}
+
+func (t *tracedUserService) CloseAccount(ctx context.Context, req *dto.CloseAccountRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CloseAccount")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.CloseAccount(ctx, req)
+}
+
+func (t *tracedUserService) SetPassword(ctx context.Context, uuid string, password string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetPassword")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.SetPassword(ctx, uuid, password)
+}
+
+func (t *tracedUserService) IssueAccessToken(ctx context.Context, uuid string, ttl time.Duration) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.IssueAccessToken")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.IssueAccessToken(ctx, uuid, ttl)
+}
+
+func (t *tracedUserService) Register(ctx context.Context, req *dto.RegisterRequest) (result *dto.RegisterRespose, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Register(ctx, req)
+}
+
+func (t *tracedUserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid string) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Update(ctx, req, uuid)
+}
+
+func (t *tracedUserService) GetUserLogin(ctx context.Context) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserLogin")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.GetUserLogin(ctx)
+}
+
+func (t *tracedUserService) GetUserByUUID(ctx context.Context, uuid string) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByUUID")
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.GetUserByUUID(ctx, uuid)
+}
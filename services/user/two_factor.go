@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+	"user-service/common/keyset"
+	"user-service/common/totp"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+	revocationServices "user-service/services/revocation"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	errConstants "user-service/constants/error"
+)
+
+const (
+	twoFactorAudience   = "two-factor"
+	recoveryCodeCount   = 10
+	totpSkew            = 1
+	defaultChallengeTTL = 5 * time.Minute
+)
+
+// ChallengeClaims is carried by the short-lived token handed out by Login when
+// a second factor is still needed. It has no User, so Authenticate never
+// accepts it as an access token.
+type ChallengeClaims struct {
+	jwt.RegisteredClaims
+}
+
+// SetupTwoFactor starts enrollment for the current user. The password is asked
+// for again so that a stolen access token is not enough to bind someone
+// else's authenticator to the account.
+func (u *UserService) SetupTwoFactor(ctx context.Context, req *dto.TwoFactorSetupRequest) (*dto.TwoFactorSetupResponse, error) {
+	userLogin := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, userLogin.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	err = comparePassword(ctx, user.Password, req.Password)
+	if err != nil {
+		return nil, errConstants.ErrPasswordIncorrect
+	}
+
+	return u.setupTwoFactor(ctx, user)
+}
+
+func (u *UserService) ConfirmTwoFactor(ctx context.Context, req *dto.TwoFactorConfirmRequest) (*dto.TwoFactorConfirmResponse, error) {
+	userLogin := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, userLogin.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return nil, errConstants.ErrTwoFactorAlreadyEnabled
+	}
+
+	err = u.checkTwoFactorCode(ctx, user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := u.enableTwoFactor(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorConfirmResponse{RecoveryCodes: codes}, nil
+}
+
+// EnrollTwoFactor lets a user whose role requires two-factor, but who has not
+// set it up yet, start enrollment with the challenge token from Login. The
+// challenge is only issued after the password was checked.
+func (u *UserService) EnrollTwoFactor(ctx context.Context, req *dto.TwoFactorEnrollRequest) (*dto.TwoFactorSetupResponse, error) {
+	user, _, err := u.parseChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.setupTwoFactor(ctx, user)
+}
+
+// VerifyTwoFactor completes a two-step login. When the challenge belongs to a
+// user in the middle of enrollment, a valid code also enables two-factor and
+// the recovery codes are returned alongside the tokens.
+func (u *UserService) VerifyTwoFactor(ctx context.Context, req *dto.TwoFactorVerifyRequest) (*dto.LoginResponse, error) {
+	user, claims, err := u.parseChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorSecret == "" {
+		return nil, errConstants.ErrTwoFactorNotSetup
+	}
+
+	err = checkClientThrottle(req.IPAddress, user.UUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkAccountThrottle(user)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolling := user.TwoFactorEnabledAt == nil
+	if req.Code != "" {
+		err = u.checkTwoFactorCode(ctx, user, req.Code)
+	} else if !enrolling {
+		err = u.repository.GetRecoveryCode().Consume(ctx, user.ID, hashToken(normalizeRecoveryCode(req.RecoveryCode)))
+	} else {
+		err = errConstants.ErrInvalidTwoFactorCode
+	}
+	if errors.Is(err, errConstants.ErrInvalidTwoFactorCode) {
+		return nil, u.recordLoginFailure(ctx, user, req.IPAddress, user.UUID.String(), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.resetLoginFailures(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	clearClientFailures(req.IPAddress, user.UUID.String())
+
+	err = revocationServices.NewRevocationService(u.repository).RevokeToken(ctx, claims.ID, user.ID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	if enrolling {
+		codes, err = u.enableTwoFactor(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := u.generateTokens(ctx, user, session{
+		familyID:  uuid.New(),
+		deviceID:  req.DeviceID,
+		userAgent: req.UserAgent,
+		ipAddress: req.IPAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = codes
+
+	return response, nil
+}
+
+func (u *UserService) issueTwoFactorChallenge(user *models.User) (*dto.LoginResponse, error) {
+	ttl := defaultChallengeTTL
+	if config.Config.TwoFactorChallengeTime > 0 {
+		ttl = time.Duration(config.Config.TwoFactorChallengeTime) * time.Minute
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	token, err := keyset.Sign(&ChallengeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    config.Config.AppName,
+			Subject:   user.UUID.String(),
+			Audience:  jwt.ClaimStrings{twoFactorAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.LoginResponse{
+		User: *loginUser(user),
+		TwoFactor: &dto.TwoFactorChallenge{
+			ChallengeToken: token,
+			ExpiresAt:      expiresAt,
+			SetupRequired:  user.TwoFactorEnabledAt == nil,
+		},
+	}
+
+	return response, nil
+}
+
+func (u *UserService) parseChallenge(ctx context.Context, token string) (*models.User, *ChallengeClaims, error) {
+	claims := &ChallengeClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, keyset.Keyfunc,
+		jwt.WithValidMethods(keyset.ValidMethods()),
+		jwt.WithAudience(twoFactorAudience),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil || !parsed.Valid || claims.ID == "" {
+		return nil, nil, errConstants.ErrInvalidChallengeToken
+	}
+
+	revoked, err := revocationServices.NewRevocationService(u.repository).IsRevoked(ctx, claims.ID, claims.Subject, claims.IssuedAt.Time)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if revoked {
+		return nil, nil, errConstants.ErrInvalidChallengeToken
+	}
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, errConstants.ErrUserNotFound) {
+			return nil, nil, errConstants.ErrInvalidChallengeToken
+		}
+		return nil, nil, err
+	}
+
+	err = checkStatus(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, claims, nil
+}
+
+// DisableTwoFactor turns two-factor off for a user who lost their
+// authenticator and recovery codes. It is meant for administrators; the user
+// sets it up again, and is made to at the next login when their role requires
+// it. Every session ends, since whoever holds one may be the reason.
+func (u *UserService) DisableTwoFactor(ctx context.Context, uuid string) error {
+	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.Transaction(ctx, func(repository repositories.IRepositoryRegistry) error {
+		err := repository.GetUser().DisableTwoFactor(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		err = repository.GetRecoveryCode().DeleteByUserID(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return revokeAllSessions(ctx, repository, uuid)
+	})
+}
+
+func (u *UserService) setupTwoFactor(ctx context.Context, user *models.User) (*dto.TwoFactorSetupResponse, error) {
+	if user.TwoFactorEnabledAt != nil {
+		return nil, errConstants.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.GetUser().SetTwoFactorSecret(ctx, user.UUID.String(), secret)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OtpauthURI: totp.URI(config.Config.AppName, user.Email, secret),
+	}
+
+	return response, nil
+}
+
+func (u *UserService) checkTwoFactorCode(ctx context.Context, user *models.User, code string) error {
+	if user.TwoFactorSecret == "" {
+		return errConstants.ErrTwoFactorNotSetup
+	}
+
+	step, ok := totp.Validate(user.TwoFactorSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return errConstants.ErrInvalidTwoFactorCode
+	}
+
+	return u.repository.GetUser().ConsumeTwoFactorStep(ctx, user.UUID.String(), step)
+}
+
+func (u *UserService) enableTwoFactor(ctx context.Context, user *models.User) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	err := u.repository.GetRecoveryCode().Replace(ctx, user.ID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.GetUser().EnableTwoFactor(ctx, user.UUID.String(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// generateRecoveryCode returns a code such as "K7QX-M2PA" that is easy to
+// write down; normalizeRecoveryCode makes the dash and case irrelevant.
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, 5)
+
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	code := base32.StdEncoding.EncodeToString(buf)
+
+	return code[:4] + "-" + code[4:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
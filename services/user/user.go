@@ -2,8 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
 	"time"
+	"user-service/clients"
+	"user-service/common/logger"
 	"user-service/config"
 	"user-service/constants"
 	"user-service/domain/dto"
@@ -11,17 +15,40 @@ import (
 	"user-service/repositories"
 
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 
 	errConstants "user-service/constants/error"
 )
 
 type UserService struct {
 	repository repositories.IRepositoryRegistry
+	client     clients.IClientRegistry
 }
 
 type IUserService interface {
 	Login(context.Context, *dto.LoginRequest) (*dto.LoginResponse, error)
+	RefreshToken(context.Context, *dto.RefreshTokenRequest) (*dto.LoginResponse, error)
+	Logout(context.Context) error
+	LogoutAll(context.Context) error
+	ForgotPassword(context.Context, *dto.ForgotPasswordRequest) error
+	ResetPassword(context.Context, *dto.ResetPasswordRequest) error
+	VerifyEmail(context.Context, *dto.VerifyEmailRequest) error
+	ResendVerification(context.Context, *dto.ResendVerificationRequest) error
+	SetupTwoFactor(context.Context, *dto.TwoFactorSetupRequest) (*dto.TwoFactorSetupResponse, error)
+	ConfirmTwoFactor(context.Context, *dto.TwoFactorConfirmRequest) (*dto.TwoFactorConfirmResponse, error)
+	EnrollTwoFactor(context.Context, *dto.TwoFactorEnrollRequest) (*dto.TwoFactorSetupResponse, error)
+	VerifyTwoFactor(context.Context, *dto.TwoFactorVerifyRequest) (*dto.LoginResponse, error)
+	DisableTwoFactor(context.Context, string) error
+	UnlockUser(context.Context, string) error
+	ListUsers(context.Context, *dto.UserListRequest) ([]dto.UserResponse, *dto.PaginationMeta, error)
+	UpdateRole(context.Context, string, *dto.UpdateUserRoleRequest) (*dto.UserResponse, error)
+	CheckStatus(context.Context, string) (*dto.AccountState, error)
+	SuspendUser(context.Context, string) error
+	ReactivateUser(context.Context, string) error
+	DeleteUser(context.Context, string) error
+	CloseAccount(context.Context, *dto.CloseAccountRequest) error
+	SetPassword(context.Context, string, string) error
+	IssueAccessToken(context.Context, string, time.Duration) (*dto.LoginResponse, error)
 	Register(context.Context, *dto.RegisterRequest) (*dto.RegisterRespose, error)
 	Update(context.Context, *dto.UpdateRequest, string) (*dto.UserResponse, error)
 	GetUserLogin(context.Context) (*dto.UserResponse, error)
@@ -29,12 +56,14 @@ type IUserService interface {
 }
 
 type Claims struct {
-	User *dto.UserResponse
+	User        *dto.UserResponse
+	SessionID   string   `json:"sid,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewUserService(repository repositories.IRepositoryRegistry) IUserService {
-	return &UserService{repository: repository}
+func NewUserService(repository repositories.IRepositoryRegistry, client clients.IClientRegistry) IUserService {
+	return &tracedUserService{next: &UserService{repository: repository, client: client}}
 }
 
 func (u *UserService) GetUserLogin(ctx context.Context) (*dto.UserResponse, error) {
@@ -44,11 +73,13 @@ func (u *UserService) GetUserLogin(ctx context.Context) (*dto.UserResponse, erro
 	)
 
 	data = dto.UserResponse{
-		UUID:  userLogin.UUID,
-		Name:  userLogin.Name,
-		Email: userLogin.Email,
-		Phone: userLogin.Phone,
-		Role:  userLogin.Role,
+		UUID:     userLogin.UUID,
+		Name:     userLogin.Name,
+		Username: userLogin.Username,
+		Email:    userLogin.Email,
+		Phone:    userLogin.Phone,
+		Role:     userLogin.Role,
+		Locale:   userLogin.Locale,
 	}
 
 	return &data, nil
@@ -61,58 +92,78 @@ func (u *UserService) GetUserByUUID(ctx context.Context, uuid string) (*dto.User
 	}
 
 	data := &dto.UserResponse{
-		UUID:  user.UUID,
-		Name:  user.Name,
-		Email: user.Email,
-		Phone: user.Phone,
+		UUID:     user.UUID,
+		Name:     user.Name,
+		Username: user.Username,
+		Email:    user.Email,
+		Phone:    user.Phone,
+		Locale:   user.Locale,
 	}
 
 	return data, nil
 }
 
 func (u *UserService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
-	user, err := u.repository.GetUser().FindByEmail(ctx, req.Username)
+	identifierType, values := resolveIdentifier(req.Username)
+	identifier := values[0]
+
+	err := checkClientThrottle(req.IPAddress, identifier)
 	if err != nil {
 		return nil, err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	user, err := u.repository.GetUser().FindByIdentifier(ctx, identifierType, values)
 	if err != nil {
+		if errors.Is(err, errConstants.ErrUserNotFound) {
+			recordClientFailure(req.IPAddress, identifier)
+		}
 		return nil, err
 	}
 
-	expirationTime := time.Now().Add(time.Duration(config.Config.JwtExpirationTime) * time.Minute).Unix()
-	data := &dto.UserResponse{
-		UUID:  user.UUID,
-		Name:  user.Name,
-		Email: user.Email,
-		Phone: user.Phone,
-		Role:  strings.ToLower(user.Role.Code),
+	err = checkAccountThrottle(user)
+	if err != nil {
+		return nil, err
 	}
 
-	Claims := &Claims{
-		User: data,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Unix(expirationTime, 0)),
-		},
+	err = comparePassword(ctx, user.Password, req.Password)
+	if err != nil {
+		return nil, u.recordLoginFailure(ctx, user, req.IPAddress, identifier, errConstants.ErrPasswordIncorrect)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims)
-	tokenString, err := token.SignedString([]byte(config.Config.JwtSecretKey))
+	// The status is only revealed to someone who knows the password.
+	err = checkStatus(user)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &dto.LoginResponse{
-		User:  *data,
-		Token: tokenString,
+	if config.Config.EmailVerificationRequired && user.EmailVerifiedAt == nil {
+		return nil, errConstants.ErrEmailNotVerified
 	}
 
-	return response, nil
+	// With two-factor the failure counter is only cleared once the second
+	// factor passes, otherwise re-entering the password would reset the
+	// throttle on code guessing.
+	if user.TwoFactorEnabledAt != nil || user.Role.TwoFactorRequired {
+		return u.issueTwoFactorChallenge(user)
+	}
+
+	err = u.resetLoginFailures(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	clearClientFailures(req.IPAddress, identifier)
+
+	return u.generateTokens(ctx, user, session{
+		familyID:  uuid.New(),
+		deviceID:  req.DeviceID,
+		userAgent: req.UserAgent,
+		ipAddress: req.IPAddress,
+	})
 }
 
 func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.RegisterRespose, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(ctx, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -125,28 +176,49 @@ func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		return nil, errConstants.ErrPhoneExists
 	}
 
+	if req.Username != nil && u.isUsernameExist(ctx, *req.Username) {
+		return nil, errConstants.ErrUsernameExists
+	}
+
 	if req.Password != req.ConfirmPassword {
 		return nil, errConstants.ErrPasswordDoesMatch
 	}
 
+	// Looked up by code: role IDs depend on the order the roles were seeded.
+	role, err := u.repository.GetRole().FindByCode(ctx, constants.CustomerCode)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := u.repository.GetUser().Register(ctx, &dto.RegisterRequest{
 		Name:     req.Name,
+		Username: req.Username,
 		Email:    req.Email,
 		Phone:    req.Phone,
 		Password: string(hashedPassword),
-		RoleID:   constants.Customer,
+		Locale:   req.Locale,
+		RoleID:   role.ID,
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	err = u.sendEmailVerification(ctx, user)
+	if err != nil {
+		// The account exists at this point; the user can ask for another link.
+		logger.FromContext(ctx).Errorf("failed to issue email verification to %s: %v", user.UUID, err)
+	}
+
 	response := &dto.RegisterRespose{
 		User: dto.UserResponse{
-			UUID:  user.UUID,
-			Name:  user.Name,
-			Email: user.Email,
-			Phone: user.Phone,
+			UUID:     user.UUID,
+			Name:     user.Name,
+			Username: user.Username,
+			Email:    user.Email,
+			Phone:    user.Phone,
+			Role:     strings.ToLower(role.Code),
+			Locale:   user.Locale,
 		},
 	}
 
@@ -166,27 +238,30 @@ func (u *UserService) isEmailExist(ctx context.Context, username string) bool {
 	return false
 }
 
-func (u *UserService) isPhoneExist(ctx context.Context, username string) bool {
-	user, err := u.repository.GetUser().FindByPhone(ctx, username)
+// isPhoneExist also matches the other formats of phone, so the same number
+// cannot be registered twice by writing it differently.
+func (u *UserService) isPhoneExist(ctx context.Context, phone string) bool {
+	_, err := u.repository.GetUser().FindByIdentifier(ctx, constants.IdentifierPhone, phoneValues(phone))
+
+	return err == nil || errors.Is(err, errConstants.ErrAmbiguousLogin)
+}
+
+func (u *UserService) isUsernameExist(ctx context.Context, username string) bool {
+	user, err := u.repository.GetUser().FindByIdentifier(ctx, constants.IdentifierUsername, []string{strings.ToLower(username)})
 	if err != nil {
 		return false
 	}
 
-	if user != nil {
-		return true
-	}
-
-	return false
+	return user != nil
 }
 
 func (u *UserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid string) (*dto.UserResponse, error) {
 	var (
-		password               string
-		checkEmail, checkPhone *models.User
-		hashedPassword         []byte
-		user, userResult       *models.User
-		err                    error
-		data                   dto.UserResponse
+		password         string
+		hashedPassword   []byte
+		user, userResult *models.User
+		err              error
+		data             dto.UserResponse
 	)
 
 	user, err = u.repository.GetUser().FindByUUID(ctx, uuid)
@@ -194,25 +269,19 @@ func (u *UserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid s
 		return nil, err
 	}
 
-	isEmailExist := u.isEmailExist(ctx, user.Email)
-	if isEmailExist && user.Email != req.Email {
-		checkEmail, err = u.repository.GetUser().FindByEmail(ctx, req.Email)
-		if err != nil {
-			return nil, err
-		}
-		if checkEmail != nil {
-			return nil, errConstants.ErrEmailExists
-		}
+	// These checks give an early answer; the unique indexes on users are what
+	// actually guarantee uniqueness under concurrent updates.
+	if !strings.EqualFold(user.Email, req.Email) && u.isEmailExist(ctx, req.Email) {
+		return nil, errConstants.ErrEmailExists
 	}
 
-	isPhoneExist := u.isPhoneExist(ctx, user.Phone)
-	if isPhoneExist && user.Phone != req.Phone {
-		checkPhone, err = u.repository.GetUser().FindByPhone(ctx, req.Phone)
-		if err != nil {
-			return nil, err
-		}
-		if checkPhone != nil {
-			return nil, errConstants.ErrPhoneExists
+	if !slices.Contains(phoneValues(user.Phone), phoneValues(req.Phone)[0]) && u.isPhoneExist(ctx, req.Phone) {
+		return nil, errConstants.ErrPhoneExists
+	}
+
+	if req.Username != nil && (user.Username == nil || !strings.EqualFold(*user.Username, *req.Username)) {
+		if u.isUsernameExist(ctx, *req.Username) {
+			return nil, errConstants.ErrUsernameExists
 		}
 	}
 
@@ -220,7 +289,7 @@ func (u *UserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid s
 		if *req.Password != *req.ConfirmPassword {
 			return nil, errConstants.ErrPasswordDoesMatch
 		}
-		hashedPassword, err = bcrypt.GenerateFromPassword([]byte(*req.Password), bcrypt.DefaultCost)
+		hashedPassword, err = hashPassword(ctx, *req.Password)
 		if err != nil {
 			return nil, err
 		}
@@ -230,20 +299,45 @@ func (u *UserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid s
 
 	userResult, err = u.repository.GetUser().Update(ctx, &dto.UpdateRequest{
 		Name:     req.Name,
+		Username: req.Username,
 		Email:    req.Email,
 		Password: &password,
 		Phone:    req.Phone,
+		Locale:   req.Locale,
 	}, uuid)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if !strings.EqualFold(user.Email, req.Email) {
+		err = u.repository.GetUser().SetEmailVerifiedAt(ctx, uuid, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Email = req.Email
+		user.Name = req.Name
+		err = u.sendEmailVerification(ctx, user)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to issue email verification to %s: %v", user.UUID, err)
+		}
+	}
+
+	if userResult.Locale == nil {
+		userResult.Locale = user.Locale
+	}
+
+	// The middleware reads the locale from the cached account state.
+	accountStates.Delete(uuid)
+
 	data = dto.UserResponse{
-		UUID:  userResult.UUID,
-		Name:  userResult.Name,
-		Email: userResult.Email,
-		Phone: userResult.Phone,
+		UUID:     userResult.UUID,
+		Name:     userResult.Name,
+		Username: userResult.Username,
+		Email:    userResult.Email,
+		Phone:    userResult.Phone,
+		Locale:   userResult.Locale,
 	}
 
 	return &data, nil